@@ -149,6 +149,161 @@ type CustomCASpec struct {
 	Key string `json:"key,omitempty"`
 }
 
+// RcloneTuningSpec contains options to tune the performance of rclone
+type RcloneTuningSpec struct {
+	// transfers is the number of files transferred in parallel. Defaults to
+	// 10.
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	Transfers *int32 `json:"transfers,omitempty"`
+	// checkers is the number of files whose equality is checked in parallel.
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	Checkers *int32 `json:"checkers,omitempty"`
+	// bandwidthLimit limits the bandwidth used by rclone. It is either a
+	// rate, such as "10M" or "10M:1M" for separate upload and download
+	// rates, or a timetable such as "08:00,512k 18:00,10M Sat-00:00,off".
+	//+optional
+	BandwidthLimit *string `json:"bandwidthLimit,omitempty"`
+	// multiThreadStreams is the number of streams used to transfer a single
+	// large file. 0 disables multi-thread transfers.
+	//+kubebuilder:validation:Minimum=0
+	//+optional
+	MultiThreadStreams *int32 `json:"multiThreadStreams,omitempty"`
+	// fastList uses fewer, recursive listings of the remote at the cost of
+	// more memory. Only has an effect on remotes that support it.
+	//+optional
+	FastList *bool `json:"fastList,omitempty"`
+	// checksum compares files by hash and size, rather than modification time
+	// and size, to determine which have changed. Defaults to true.
+	//+optional
+	Checksum *bool `json:"checksum,omitempty"`
+}
+
+// RcloneEncryptionSpec configures client-side encryption of the data written
+// to the rclone remote
+type RcloneEncryptionSpec struct {
+	// secretName is the name of a Secret containing the encryption password
+	// (key "password") and, optionally, the salt (key "salt"). The values are
+	// used as-is, they must not be obscured.
+	SecretName string `json:"secretName"`
+	// filenameEncryption determines how file names are encrypted.
+	//+kubebuilder:validation:Enum=standard;obfuscate;off
+	//+kubebuilder:default=standard
+	//+optional
+	FilenameEncryption *string `json:"filenameEncryption,omitempty"`
+	// directoryNameEncryption determines whether directory names are
+	// encrypted. Ignored when filenameEncryption is off.
+	//+kubebuilder:default=true
+	//+optional
+	DirectoryNameEncryption *bool `json:"directoryNameEncryption,omitempty"`
+}
+
+// RcloneVersioningSpec configures keeping the previous versions of files that
+// are replaced or deleted on the rclone remote
+type RcloneVersioningSpec struct {
+	// keep is the number of version directories to retain. If not set,
+	// versions are not removed based on their count.
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	Keep *int32 `json:"keep,omitempty"`
+	// maxAgeDays is the number of days after which a version directory is
+	// removed. If not set, versions are not removed based on their age.
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	MaxAgeDays *int32 `json:"maxAgeDays,omitempty"`
+}
+
+// ConfigMapKeySpec references a key within a ConfigMap in the same namespace
+type ConfigMapKeySpec struct {
+	// The name of the ConfigMap
+	ConfigMapName string `json:"configMapName"`
+
+	// The key within the ConfigMap
+	Key string `json:"key"`
+}
+
+// ResticTuningSpec contains options to tune the performance of restic
+type ResticTuningSpec struct {
+	// compression is the compression mode used for data written to the
+	// repository. Only applies to repositories using format version 2.
+	//+kubebuilder:validation:Enum=auto;off;fastest;better;max
+	//+optional
+	Compression *string `json:"compression,omitempty"`
+	// packSizeMiB is the target size, in MiB, of the pack files written to
+	// the repository.
+	//+kubebuilder:validation:Minimum=4
+	//+kubebuilder:validation:Maximum=128
+	//+optional
+	PackSizeMiB *int32 `json:"packSizeMiB,omitempty"`
+	// limitUploadKiB limits the upload bandwidth to the repository, in KiB/s.
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	LimitUploadKiB *int32 `json:"limitUploadKiB,omitempty"`
+	// limitDownloadKiB limits the download bandwidth from the repository, in
+	// KiB/s.
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	LimitDownloadKiB *int32 `json:"limitDownloadKiB,omitempty"`
+	// readConcurrency is the number of files that are read concurrently
+	// during a backup. Ignored for restores.
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	ReadConcurrency *int32 `json:"readConcurrency,omitempty"`
+	// s3Connections is the number of concurrent connections made to an S3
+	// repository.
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	S3Connections *int32 `json:"s3Connections,omitempty"`
+}
+
+// RsyncTuningSpec contains options to tune how rsync transfers data
+type RsyncTuningSpec struct {
+	// bandwidthLimitKiB limits the transfer bandwidth, in KiB/s.
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	BandwidthLimitKiB *int32 `json:"bandwidthLimitKiB,omitempty"`
+	// compression enables compression of the data sent to the destination.
+	// Defaults to true.
+	//+optional
+	Compression *bool `json:"compression,omitempty"`
+	// compressionLevel is the compression level, from 1 (fastest) to 9 (best
+	// compression). Only applies when compression is enabled.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=9
+	//+optional
+	CompressionLevel *int32 `json:"compressionLevel,omitempty"`
+	// checksum compares files using a checksum of their contents rather than
+	// their size and modification time. This is slower, but detects changes
+	// that leave the size and modification time unchanged.
+	//+optional
+	Checksum *bool `json:"checksum,omitempty"`
+	// partial keeps partially transferred files so that an interrupted
+	// transfer of a large file can be resumed by the next attempt.
+	//+optional
+	Partial *bool `json:"partial,omitempty"`
+}
+
+// RcloneModeType defines how rclone transfers data between the volume and the
+// remote.
+// +kubebuilder:validation:Enum=sync;copy;move;bisync
+type RcloneModeType string
+
+const (
+	// RcloneModeSync makes the target identical to the origin, deleting files
+	// from the target that are not present in the origin.
+	RcloneModeSync RcloneModeType = "sync"
+	// RcloneModeCopy copies new and changed files to the target without
+	// deleting anything from it.
+	RcloneModeCopy RcloneModeType = "copy"
+	// RcloneModeMove copies files to the target and then deletes them from the
+	// origin.
+	RcloneModeMove RcloneModeType = "move"
+	// RcloneModeBisync propagates changes in both directions between the
+	// volume and the remote.
+	RcloneModeBisync RcloneModeType = "bisync"
+)
+
 type MoverConfig struct {
 	// MoverSecurityContext allows specifying the PodSecurityContext that will
 	// be used by the data mover
@@ -108,6 +108,18 @@ type ReplicationDestinationRsyncSpec struct {
 	// authentication. If not provided, the keys will be generated.
 	//+optional
 	SSHKeys *string `json:"sshKeys,omitempty"`
+	// keyRotationInterval is how often the generated SSH keys are rotated (e.g.
+	// "720h"). Rotation only applies when sshKeys is not provided.
+	//+optional
+	KeyRotationInterval *metav1.Duration `json:"keyRotationInterval,omitempty"`
+	// keyRotationToken can be set to any value to request a rotation of the
+	// generated SSH keys. Changing the value triggers another rotation.
+	//+optional
+	KeyRotationToken *string `json:"keyRotationToken,omitempty"`
+	// keyRotationGracePeriod is how long the previous key remains accepted
+	// after a rotation. Defaults to 24h.
+	//+optional
+	KeyRotationGracePeriod *metav1.Duration `json:"keyRotationGracePeriod,omitempty"`
 	// serviceType determines the Service type that will be created for incoming
 	// SSH connections.
 	//+optional
@@ -153,6 +165,10 @@ type ReplicationDestinationRsyncSpec struct {
 // ReplicationDestinationRcloneSpec defines the field for rclone in replicationDestination.
 type ReplicationDestinationRcloneSpec struct {
 	ReplicationDestinationVolumeOptions `json:",inline"`
+	// Will be used for the dynamic destination PVC created by VolSync.
+	// Defaults to "Filesystem"
+	//+optional
+	VolumeMode *corev1.PersistentVolumeMode `json:"volumeMode,omitempty"`
 	//RcloneConfigSection is the section in rclone_config file to use for the current job.
 	RcloneConfigSection *string `json:"rcloneConfigSection,omitempty"`
 	// RcloneDestPath is the remote path to sync to.
@@ -161,6 +177,46 @@ type ReplicationDestinationRcloneSpec struct {
 	RcloneConfig *string `json:"rcloneConfig,omitempty"`
 	// customCA is a custom CA that will be used to verify the remote
 	CustomCA CustomCASpec `json:"customCA,omitempty"`
+	// mode is the rclone operation used to transfer data. sync (the default)
+	// deletes files from the volume that are not present in the remote, and
+	// copy never deletes. move and bisync would modify the remote, so they are
+	// rejected on a ReplicationDestination.
+	//+kubebuilder:default=sync
+	//+optional
+	Mode *RcloneModeType `json:"mode,omitempty"`
+	// filters are rclone filter rules, applied in order before any rules in
+	// filterConfigMap. Each rule is either "+ pattern" to include or
+	// "- pattern" to exclude matching files.
+	//+optional
+	Filters []string `json:"filters,omitempty"`
+	// filterConfigMap references an rclone filter file held in a ConfigMap.
+	//+optional
+	FilterConfigMap *ConfigMapKeySpec `json:"filterConfigMap,omitempty"`
+	// encryption encrypts the data on the remote using an rclone crypt remote
+	// layered over rcloneConfigSection. The source and destination must use
+	// the same settings.
+	//+optional
+	Encryption *RcloneEncryptionSpec `json:"encryption,omitempty"`
+	// restoreVersion is the name of a version directory (YYYYMMDD-hhmmss)
+	// created by a ReplicationSource using versioning. The data is restored as
+	// it was before the synchronization that created the version.
+	//+kubebuilder:validation:Pattern=`^[0-9]{8}-[0-9]{6}$`
+	//+optional
+	RestoreVersion *string `json:"restoreVersion,omitempty"`
+	// verify runs rclone check after the transfer and fails the
+	// synchronization if the volume and the remote differ. size compares the
+	// file sizes, hash also compares the file hashes where the remote supports
+	// them, using rclone cryptcheck with encryption.
+	//+kubebuilder:validation:Enum=size;hash
+	//+optional
+	Verify *string `json:"verify,omitempty"`
+	// tuning contains options to tune the performance of rclone
+	//+optional
+	Tuning *RcloneTuningSpec `json:"tuning,omitempty"`
+	// blockChunkSize must be set if the ReplicationSource split the image of
+	// a block volume into chunks. The size itself does not need to match.
+	//+optional
+	BlockChunkSize *resource.Quantity `json:"blockChunkSize,omitempty"`
 
 	MoverConfig `json:",inline"`
 }
@@ -220,6 +276,17 @@ type ReplicationDestinationRsyncStatus struct {
 	// connections.
 	//+optional
 	Port *int32 `json:"port,omitempty"`
+	// lastKeyRotation is the time the generated SSH keys were last rotated.
+	//+optional
+	LastKeyRotation *metav1.Time `json:"lastKeyRotation,omitempty"`
+	// keyRotationToken is the value of .spec.rsync.keyRotationToken that was
+	// handled by the most recent rotation.
+	//+optional
+	KeyRotationToken *string `json:"keyRotationToken,omitempty"`
+	// previousKeyExpiration is the time until which the key in use before the
+	// most recent rotation remains accepted.
+	//+optional
+	PreviousKeyExpiration *metav1.Time `json:"previousKeyExpiration,omitempty"`
 }
 
 type ReplicationDestinationResticCA CustomCASpec
@@ -227,6 +294,10 @@ type ReplicationDestinationResticCA CustomCASpec
 // ReplicationDestinationResticSpec defines the field for restic in replicationDestination.
 type ReplicationDestinationResticSpec struct {
 	ReplicationDestinationVolumeOptions `json:",inline"`
+	// Will be used for the dynamic destination PVC created by VolSync.
+	// Defaults to "Filesystem"
+	//+optional
+	VolumeMode *corev1.PersistentVolumeMode `json:"volumeMode,omitempty"`
 	// Repository is the secret name containing repository info
 	Repository string `json:"repository,omitempty"`
 	// customCA is a custom CA that will be used to verify the remote
@@ -260,10 +331,49 @@ type ReplicationDestinationResticSpec struct {
 	// Defaults to false.
 	//+optional
 	EnableFileDeletion bool `json:"enableFileDeletion,omitempty"`
+	// tuning contains options to tune the performance of restic
+	//+optional
+	Tuning *ResticTuningSpec `json:"tuning,omitempty"`
+	// dryRun previews the restore instead of performing it. The restore is
+	// compared against the current contents of the destination PVC and the
+	// number of files that would be added, changed and deleted is reported in
+	// status.restic.preview. No data is modified and no latestImage is
+	// produced. Not supported for block volumes.
+	//+optional
+	DryRun bool `json:"dryRun,omitempty"`
 
 	MoverConfig `json:",inline"`
 }
 
+// ReplicationDestinationResticStatus defines the field for restic in
+// ReplicationDestinationStatus
+type ReplicationDestinationResticStatus struct {
+	// preview contains the result of the most recent restore preview (dryRun).
+	//+optional
+	Preview *ReplicationDestinationResticPreviewStatus `json:"preview,omitempty"`
+}
+
+// ReplicationDestinationResticPreviewStatus reports what a restore would change
+// on the destination PVC
+type ReplicationDestinationResticPreviewStatus struct {
+	// snapshot is the ID of the restic snapshot that was previewed.
+	//+optional
+	Snapshot string `json:"snapshot,omitempty"`
+	// filesToAdd is the number of files in the snapshot that do not exist on
+	// the PVC.
+	FilesToAdd int64 `json:"filesToAdd"`
+	// filesToChange is the number of files on the PVC that differ from the
+	// snapshot.
+	FilesToChange int64 `json:"filesToChange"`
+	// filesToDelete is the number of files and directories on the PVC that are
+	// not in the snapshot and would be removed. This is only non-zero when
+	// enableFileDeletion is set.
+	FilesToDelete int64 `json:"filesToDelete"`
+	// lastPreviewed is the time the preview was made.
+	//+optional
+	LastPreviewed *metav1.Time `json:"lastPreviewed,omitempty"`
+}
+
 // ReplicationDestinationStatus defines the observed state of ReplicationDestination
 type ReplicationDestinationStatus struct {
 	// lastSyncTime is the time of the most recent successful synchronization.
@@ -294,6 +404,9 @@ type ReplicationDestinationStatus struct {
 	Rsync *ReplicationDestinationRsyncStatus `json:"rsync,omitempty"`
 	// rsyncTLS contains status information for Rsync-based replication over TLS.
 	RsyncTLS *ReplicationDestinationRsyncTLSStatus `json:"rsyncTLS,omitempty"`
+	// restic contains status information for Restic-based replication.
+	//+optional
+	Restic *ReplicationDestinationResticStatus `json:"restic,omitempty"`
 	// external contains provider-specific status information. For more details,
 	// please see the documentation of the specific replication provider being
 	// used.
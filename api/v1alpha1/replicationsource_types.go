@@ -99,6 +99,18 @@ type ReplicationSourceRsyncSpec struct {
 	// authentication. If not provided, the keys will be generated.
 	//+optional
 	SSHKeys *string `json:"sshKeys,omitempty"`
+	// keyRotationInterval is how often the generated SSH keys are rotated (e.g.
+	// "720h"). Rotation only applies when sshKeys is not provided.
+	//+optional
+	KeyRotationInterval *metav1.Duration `json:"keyRotationInterval,omitempty"`
+	// keyRotationToken can be set to any value to request a rotation of the
+	// generated SSH keys. Changing the value triggers another rotation.
+	//+optional
+	KeyRotationToken *string `json:"keyRotationToken,omitempty"`
+	// keyRotationGracePeriod is how long the previous key remains accepted
+	// after a rotation. Defaults to 24h.
+	//+optional
+	KeyRotationGracePeriod *metav1.Duration `json:"keyRotationGracePeriod,omitempty"`
 	// serviceType determines the Service type that will be created for incoming
 	// SSH connections.
 	//+optional
@@ -117,6 +129,18 @@ type ReplicationSourceRsyncSpec struct {
 	// sshUser is the username for outgoing SSH connections. Defaults to "root".
 	//+optional
 	SSHUser *string `json:"sshUser,omitempty"`
+	// tuning contains options to tune how rsync transfers data.
+	//+optional
+	Tuning *RsyncTuningSpec `json:"tuning,omitempty"`
+	// excludePatterns is a list of rsync exclude patterns. Matching files are
+	// neither sent to the destination nor deleted from it.
+	//+optional
+	ExcludePatterns []string `json:"excludePatterns,omitempty"`
+	// excludeConfigMap references a key within a ConfigMap that holds an rsync
+	// exclude file, with one pattern per line. It is applied in addition to
+	// excludePatterns.
+	//+optional
+	ExcludeConfigMap *ConfigMapKeySpec `json:"excludeConfigMap,omitempty"`
 	// MoverServiceAccount allows specifying the name of the service account
 	// that will be used by the data mover. This should only be used by advanced
 	// users who want to override the service account normally used by the mover.
@@ -147,6 +171,49 @@ type ReplicationSourceRcloneSpec struct {
 	RcloneConfig *string `json:"rcloneConfig,omitempty"`
 	// customCA is a custom CA that will be used to verify the remote
 	CustomCA CustomCASpec `json:"customCA,omitempty"`
+	// mode is the rclone operation used to transfer data. sync (the default)
+	// deletes files from the target that are not present in the origin, copy
+	// never deletes, move deletes files from the origin once transferred, and
+	// bisync propagates changes in both directions.
+	// WARNING: move removes the data from the source volume after each upload,
+	// and requires copyMethod Direct so that it applies to the live volume.
+	//+kubebuilder:default=sync
+	//+optional
+	Mode *RcloneModeType `json:"mode,omitempty"`
+	// filters are rclone filter rules, applied in order before any rules in
+	// filterConfigMap. Each rule is either "+ pattern" to include or
+	// "- pattern" to exclude matching files.
+	//+optional
+	Filters []string `json:"filters,omitempty"`
+	// filterConfigMap references an rclone filter file held in a ConfigMap.
+	//+optional
+	FilterConfigMap *ConfigMapKeySpec `json:"filterConfigMap,omitempty"`
+	// encryption encrypts the data on the remote using an rclone crypt remote
+	// layered over rcloneConfigSection. The source and destination must use
+	// the same settings.
+	//+optional
+	Encryption *RcloneEncryptionSpec `json:"encryption,omitempty"`
+	// versioning moves the files that each synchronization replaces or deletes
+	// on the remote into a version directory named after the time of the
+	// synchronization (YYYYMMDD-hhmmss, UTC) under .volsync-versions. Not
+	// supported with the bisync mode.
+	//+optional
+	Versioning *RcloneVersioningSpec `json:"versioning,omitempty"`
+	// verify runs rclone check after the transfer and fails the
+	// synchronization if the volume and the remote differ. size compares the
+	// file sizes, hash also compares the file hashes where the remote supports
+	// them, using rclone cryptcheck with encryption. Not supported with the
+	// move mode.
+	//+kubebuilder:validation:Enum=size;hash
+	//+optional
+	Verify *string `json:"verify,omitempty"`
+	// tuning contains options to tune the performance of rclone
+	//+optional
+	Tuning *RcloneTuningSpec `json:"tuning,omitempty"`
+	// blockChunkSize splits the image of a block volume into chunks of this
+	// size on the remote. If not set, the image is stored as a single object.
+	//+optional
+	BlockChunkSize *resource.Quantity `json:"blockChunkSize,omitempty"`
 
 	MoverConfig `json:",inline"`
 }
@@ -178,6 +245,32 @@ type ResticRetainPolicy struct {
 
 type ReplicationSourceResticCA CustomCASpec
 
+// ResticCopyToSpec defines a secondary restic repository that each new backup
+// is copied to.
+type ResticCopyToSpec struct {
+	// Repository is the secret name containing the secondary repository info
+	Repository string `json:"repository"`
+	// Retain defines the retain policy for the secondary repository. If not
+	// set, the retain policy of the primary repository is used.
+	//+optional
+	Retain *ResticRetainPolicy `json:"retain,omitempty"`
+}
+
+// ResticMaintenanceSpec defines a schedule for repository maintenance that is
+// independent of the backup schedule.
+type ResticMaintenanceSpec struct {
+	// schedule is a cronspec (https://en.wikipedia.org/wiki/Cron#Overview)
+	// that determines when restic prune (and check, if enabled) will run.
+	// Maintenance runs in its own Job and does not delay backups.
+	// nolint:lll
+	//+kubebuilder:validation:Pattern=`^(@(annually|yearly|monthly|weekly|daily|hourly))|((((\d+,)*\d+|(\d+(\/|-)\d+)|\*(\/\d+)?)\s?){5})$`
+	Schedule string `json:"schedule"`
+	// check enables running restic check after the repository is pruned to
+	// verify its integrity.
+	//+optional
+	Check bool `json:"check,omitempty"`
+}
+
 // ReplicationSourceResticSpec defines the field for restic in replicationSource.
 type ReplicationSourceResticSpec struct {
 	ReplicationSourceVolumeOptions `json:",inline"`
@@ -209,6 +302,19 @@ type ReplicationSourceResticSpec struct {
 	// then ran a backup.
 	// Unlock will not be run again unless spec.restic.unlock is set to a different value.
 	Unlock string `json:"unlock,omitempty"`
+	// copyTo defines a secondary repository. After each successful backup,
+	// the new snapshot is copied into this repository using restic copy.
+	//+optional
+	CopyTo *ResticCopyToSpec `json:"copyTo,omitempty"`
+	// maintenance schedules restic prune and check to run as separate Jobs,
+	// independent of the backup schedule. Only one maintenance Job runs at a
+	// time for each repository, even when the repository is shared by
+	// multiple ReplicationSources. When set, pruneIntervalDays is ignored.
+	//+optional
+	Maintenance *ResticMaintenanceSpec `json:"maintenance,omitempty"`
+	// tuning contains options to tune the performance of restic
+	//+optional
+	Tuning *ResticTuningSpec `json:"tuning,omitempty"`
 
 	MoverConfig `json:",inline"`
 }
@@ -222,6 +328,43 @@ type ReplicationSourceResticStatus struct {
 	// restic repository.
 	//+optional
 	LastUnlocked string `json:"lastUnlocked,omitempty"`
+	// copyTo contains status information for the copy of backups into the
+	// secondary repository.
+	//+optional
+	CopyTo *ReplicationSourceResticCopyToStatus `json:"copyTo,omitempty"`
+	// lastChecked is the time of the last successful restic check of the
+	// repository.
+	//+optional
+	LastChecked *metav1.Time `json:"lastChecked,omitempty"`
+	// nextMaintenance is the time when the next scheduled maintenance will
+	// start.
+	//+optional
+	NextMaintenance *metav1.Time `json:"nextMaintenance,omitempty"`
+	// latestMaintenanceStatus contains the result and logs of the most recent
+	// maintenance Job.
+	//+optional
+	LatestMaintenanceStatus *MoverStatus `json:"latestMaintenanceStatus,omitempty"`
+}
+
+// ReplicationSourceResticCopyToStatus defines the status of copying backups
+// into the secondary repository
+type ReplicationSourceResticCopyToStatus struct {
+	// repository is the name of the secret for the secondary repository that
+	// the last copy was made to.
+	//+optional
+	Repository string `json:"repository,omitempty"`
+	// lastCopied is the time of the most recent successful copy of a backup
+	// into the secondary repository.
+	//+optional
+	LastCopied *metav1.Time `json:"lastCopied,omitempty"`
+	// snapshot is the ID of the snapshot in the primary repository that the
+	// most recent copy was of.
+	//+optional
+	Snapshot string `json:"snapshot,omitempty"`
+	// result is the result of the most recent copy. A failed copy fails the
+	// synchronization, which is then retried.
+	//+optional
+	Result MoverResult `json:"result,omitempty"`
 }
 
 // define the Syncthing field
@@ -240,6 +383,18 @@ type ReplicationSourceSyncthingSpec struct {
 	// Used to set the accessModes of Syncthing config volume.
 	//+optional
 	ConfigAccessModes []corev1.PersistentVolumeAccessMode `json:"configAccessModes,omitempty"`
+	// ignorePatterns is a list of Syncthing ignore patterns for the synced
+	// folder. Matching files are neither sent to nor updated from the peers.
+	// When ignorePatterns or ignoreConfigMap is set, the patterns replace the
+	// contents of the folder's .stignore file, after the default lost+found
+	// pattern.
+	//+optional
+	IgnorePatterns []string `json:"ignorePatterns,omitempty"`
+	// ignoreConfigMap references a key within a ConfigMap that holds Syncthing
+	// ignore patterns, with one pattern per line. They are applied after
+	// ignorePatterns.
+	//+optional
+	IgnoreConfigMap *ConfigMapKeySpec `json:"ignoreConfigMap,omitempty"`
 
 	MoverConfig `json:",inline"`
 }
@@ -291,6 +446,17 @@ type ReplicationSourceRsyncStatus struct {
 	// connections.
 	//+optional
 	Port *int32 `json:"port,omitempty"`
+	// lastKeyRotation is the time the generated SSH keys were last rotated.
+	//+optional
+	LastKeyRotation *metav1.Time `json:"lastKeyRotation,omitempty"`
+	// keyRotationToken is the value of .spec.rsync.keyRotationToken that was
+	// handled by the most recent rotation.
+	//+optional
+	KeyRotationToken *string `json:"keyRotationToken,omitempty"`
+	// previousKeyExpiration is the time until which the key in use before the
+	// most recent rotation remains accepted.
+	//+optional
+	PreviousKeyExpiration *metav1.Time `json:"previousKeyExpiration,omitempty"`
 }
 
 type ReplicationSourceSyncthingStatus struct {
@@ -300,6 +466,11 @@ type ReplicationSourceSyncthingStatus struct {
 	ID string `json:"ID,omitempty"`
 	// Service address where Syncthing is exposed to the rest of the world
 	Address string `json:"address,omitempty"`
+	// ignorePatterns are the patterns of the .stignore file most recently set
+	// from the spec. They are reset to the defaults once the spec no longer
+	// sets any.
+	//+optional
+	IgnorePatterns []string `json:"ignorePatterns,omitempty"`
 }
 
 // ReplicationSourceStatus defines the observed state of ReplicationSource
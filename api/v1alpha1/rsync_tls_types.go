@@ -34,7 +34,10 @@ limitations under the License.
 // +kubebuilder:validation:Required
 package v1alpha1
 
-import corev1 "k8s.io/api/core/v1"
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 /********************************************************************
  * Replication source types
@@ -44,8 +47,20 @@ type ReplicationSourceRsyncTLSSpec struct {
 	ReplicationSourceVolumeOptions `json:",inline"`
 	// keySecret is the name of a Secret that contains the TLS pre-shared key to
 	// be used for authentication. If not provided, the key will be generated.
+	// The key Secret may contain several identities, one per line, and the
+	// destination accepts all of them.
 	//+optional
 	KeySecret *string `json:"keySecret,omitempty"`
+	// pskIdentity selects the identity from the key Secret that is used to
+	// authenticate to the destination. Defaults to the first identity in the
+	// key Secret.
+	//+optional
+	PSKIdentity *string `json:"pskIdentity,omitempty"`
+	// certificate configures authentication with X.509 certificates (mutual
+	// TLS) instead of a pre-shared key. keySecret must not be set when a
+	// certificate is used.
+	//+optional
+	Certificate *RsyncTLSCertificateSpec `json:"certificate,omitempty"`
 	// address is the remote address to connect to for replication.
 	//+optional
 	Address *string `json:"address,omitempty"`
@@ -54,16 +69,104 @@ type ReplicationSourceRsyncTLSSpec struct {
 	//+kubebuilder:validation:Maximum=65535
 	//+optional
 	Port *int32 `json:"port,omitempty"`
+	// tuning contains options to tune how rsync transfers data.
+	//+optional
+	Tuning *RsyncTuningSpec `json:"tuning,omitempty"`
+	// excludePatterns is a list of rsync exclude patterns. Matching files are
+	// neither sent to the destination nor deleted from it.
+	//+optional
+	ExcludePatterns []string `json:"excludePatterns,omitempty"`
+	// excludeConfigMap references a key within a ConfigMap that holds an rsync
+	// exclude file, with one pattern per line. It is applied in addition to
+	// excludePatterns.
+	//+optional
+	ExcludeConfigMap *ConfigMapKeySpec `json:"excludeConfigMap,omitempty"`
+	// targets is a list of destinations that each receive a copy of the data
+	// during every synchronization. A single point-in-time copy of the source
+	// is shared by all targets, with one mover Job per target. address, port,
+	// keySecret and pskIdentity must not be set when targets are used.
+	//+listType=map
+	//+listMapKey=name
+	//+optional
+	Targets []RsyncTLSTargetSpec `json:"targets,omitempty"`
+	// blockTransfer contains options for replicating volumes with
+	// volumeMode: Block.
+	//+optional
+	BlockTransfer *RsyncTLSBlockTransferSpec `json:"blockTransfer,omitempty"`
 
 	MoverConfig `json:",inline"`
 }
 
+// RsyncTLSTargetSpec is one of several destinations of a ReplicationSource.
+type RsyncTLSTargetSpec struct {
+	// name identifies the target in the status and in the names of the
+	// objects created for it.
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:MaxLength=20
+	//+kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// address is the remote address to connect to for replication.
+	Address string `json:"address"`
+	// port is the port to connect to for replication. Defaults to 8000.
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=65535
+	//+optional
+	Port *int32 `json:"port,omitempty"`
+	// keySecret is the name of a Secret that contains the TLS pre-shared key
+	// for this target. It is required unless a certificate is used.
+	//+optional
+	KeySecret *string `json:"keySecret,omitempty"`
+	// pskIdentity selects the identity from the key Secret that is used to
+	// authenticate to this target.
+	//+optional
+	PSKIdentity *string `json:"pskIdentity,omitempty"`
+	// peerName overrides certificate.peerName for this target.
+	//+optional
+	PeerName *string `json:"peerName,omitempty"`
+}
+
 type ReplicationSourceRsyncTLSStatus struct {
 	// keySecret is the name of a Secret that contains the TLS pre-shared key to
 	// be used for authentication. If not provided in .spec.rsyncTLS.keySecret,
 	// the key Secret will be generated and named here.
 	//+optional
 	KeySecret *string `json:"keySecret,omitempty"`
+	// lastPSKIdentity is the pre-shared key identity that was used by the most
+	// recent successful synchronization.
+	//+optional
+	LastPSKIdentity *string `json:"lastPSKIdentity,omitempty"`
+	// targets contains the status of each target in .spec.rsyncTLS.targets.
+	//+listType=map
+	//+listMapKey=name
+	//+optional
+	Targets []RsyncTLSTargetStatus `json:"targets,omitempty"`
+	// baseSnapshot is the name of the VolumeSnapshot of the most recent
+	// synchronization, kept to find the blocks that changed since then when
+	// .spec.rsyncTLS.blockTransfer.changedBlocks is set.
+	//+optional
+	BaseSnapshot *string `json:"baseSnapshot,omitempty"`
+}
+
+// RsyncTLSTargetStatus is the status of the replication to one target.
+type RsyncTLSTargetStatus struct {
+	// name of the target in .spec.rsyncTLS.targets.
+	Name string `json:"name"`
+	// lastSyncTime is the time the most recent transfer to this target
+	// completed.
+	//+optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// lastSyncDuration is the amount of time the most recent transfer to this
+	// target took.
+	//+optional
+	LastSyncDuration *metav1.Duration `json:"lastSyncDuration,omitempty"`
+	// lastPSKIdentity is the pre-shared key identity that was used by the most
+	// recent successful transfer to this target.
+	//+optional
+	LastPSKIdentity *string `json:"lastPSKIdentity,omitempty"`
+	// latestMoverStatus contains the logs and completion status of the mover
+	// for this target.
+	//+optional
+	LatestMoverStatus *MoverStatus `json:"latestMoverStatus,omitempty"`
 }
 
 /********************************************************************
@@ -78,8 +181,15 @@ type ReplicationDestinationRsyncTLSSpec struct {
 	VolumeMode *corev1.PersistentVolumeMode `json:"volumeMode,omitempty"`
 	// keySecret is the name of a Secret that contains the TLS pre-shared key to
 	// be used for authentication. If not provided, the key will be generated.
+	// The key Secret may contain several identities, one per line, and the
+	// destination accepts all of them.
 	//+optional
 	KeySecret *string `json:"keySecret,omitempty"`
+	// certificate configures authentication with X.509 certificates (mutual
+	// TLS) instead of a pre-shared key. keySecret must not be set when a
+	// certificate is used.
+	//+optional
+	Certificate *RsyncTLSCertificateSpec `json:"certificate,omitempty"`
 	// serviceType determines the Service type that will be created for incoming
 	// TLS connections.
 	//+optional
@@ -89,6 +199,21 @@ type ReplicationDestinationRsyncTLSSpec struct {
 	// will be used instead of any VolSync default values.
 	//+optional
 	ServiceAnnotations *map[string]string `json:"serviceAnnotations,omitempty"`
+	// persistent runs the destination mover as a long-running Deployment that
+	// accepts successive synchronizations, instead of starting a new mover Job
+	// for each synchronization. A new image is still created after each
+	// completed transfer.
+	//+optional
+	Persistent *bool `json:"persistent,omitempty"`
+	// route exposes the destination through an OpenShift Route with TLS
+	// passthrough. The hostname of the Route is published in
+	// .status.rsyncTLS.address.
+	//+optional
+	Route *RsyncTLSRouteSpec `json:"route,omitempty"`
+	// tlsRoute exposes the destination through a Gateway API TLSRoute. The
+	// hostname of the TLSRoute is published in .status.rsyncTLS.address.
+	//+optional
+	TLSRoute *RsyncTLSGatewayRouteSpec `json:"tlsRoute,omitempty"`
 
 	MoverConfig `json:",inline"`
 }
@@ -99,6 +224,10 @@ type ReplicationDestinationRsyncTLSStatus struct {
 	// the key Secret will be generated and named here.
 	//+optional
 	KeySecret *string `json:"keySecret,omitempty"`
+	// lastPSKIdentity is the pre-shared key identity that was used by the most
+	// recent successful synchronization.
+	//+optional
+	LastPSKIdentity *string `json:"lastPSKIdentity,omitempty"`
 	// address is the address to connect to for incoming TLS connections.
 	//+optional
 	Address *string `json:"address,omitempty"`
@@ -106,3 +235,105 @@ type ReplicationDestinationRsyncTLSStatus struct {
 	//+optional
 	Port *int32 `json:"port,omitempty"`
 }
+
+/********************************************************************
+ * Common types
+ ********************************************************************/
+
+// RsyncTLSCertificateSpec configures mutual TLS authentication with X.509
+// certificates.
+type RsyncTLSCertificateSpec struct {
+	// secretName is the name of a Secret that contains the certificate
+	// (tls.crt) and private key (tls.key) presented to the remote side, such
+	// as a Secret issued by cert-manager.
+	SecretName string `json:"secretName"`
+	// caBundle references the CA certificate(s) used to verify the remote
+	// side's certificate. If not provided, the ca.crt key of the certificate
+	// Secret is used.
+	//+optional
+	CABundle *CustomCASpec `json:"caBundle,omitempty"`
+	// peerName is a DNS name that the remote side's certificate must be valid
+	// for. If not provided, any certificate issued by the CA is accepted.
+	//+optional
+	PeerName *string `json:"peerName,omitempty"`
+}
+
+// RsyncTLSRouteSpec configures an OpenShift Route with TLS passthrough for
+// the destination.
+type RsyncTLSRouteSpec struct {
+	// host is the hostname of the Route. If not provided, the router assigns
+	// one.
+	//+optional
+	Host *string `json:"host,omitempty"`
+}
+
+// RsyncTLSGatewayRouteSpec configures a Gateway API TLSRoute for the
+// destination.
+type RsyncTLSGatewayRouteSpec struct {
+	// hostname is the name that sources use to connect to the destination
+	// through the Gateway.
+	//+kubebuilder:validation:MinLength=1
+	Hostname string `json:"hostname"`
+	// gatewayName is the name of the Gateway that the TLSRoute attaches to.
+	//+kubebuilder:validation:MinLength=1
+	GatewayName string `json:"gatewayName"`
+	// gatewayNamespace is the namespace of the Gateway. Defaults to the
+	// namespace of the ReplicationDestination.
+	//+optional
+	GatewayNamespace *string `json:"gatewayNamespace,omitempty"`
+	// sectionName selects a listener of the Gateway.
+	//+optional
+	SectionName *string `json:"sectionName,omitempty"`
+	// port is the port of the Gateway listener, published in
+	// .status.rsyncTLS.port. Defaults to 443.
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=65535
+	//+optional
+	Port *int32 `json:"port,omitempty"`
+}
+
+// RsyncTLSBlockTransferSpec configures how the data of Block volumes is
+// transferred.
+type RsyncTLSBlockTransferSpec struct {
+	// resumable transfers the volume in segments whose progress is recorded by
+	// the destination, so that a transfer that is interrupted resumes where it
+	// left off instead of starting over. The destination must run a version of
+	// VolSync that supports resumable transfers.
+	//+optional
+	Resumable *bool `json:"resumable,omitempty"`
+	// changedBlocks keeps the snapshot of the most recent synchronization and
+	// uses the CSI SnapshotMetadata service of the storage driver to transfer
+	// only the blocks that changed since then. It requires the Snapshot
+	// copyMethod and is not used with multiple targets. The whole volume is
+	// transferred when the service is not available. The destination volume
+	// must not be modified between synchronizations.
+	//+optional
+	ChangedBlocks *bool `json:"changedBlocks,omitempty"`
+	// compression of the data sent to the destination. With zstd, the data is
+	// compressed using compressionLevel. The default is none.
+	//+kubebuilder:validation:Enum=none;zstd
+	//+optional
+	Compression *string `json:"compression,omitempty"`
+	// compressionLevel is the zstd compression level, from 1 (fastest) to 22
+	// (smallest). The default is 3.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=22
+	//+optional
+	CompressionLevel *int32 `json:"compressionLevel,omitempty"`
+	// streams is the number of parts of the volume that are transferred over
+	// parallel connections. It applies when the whole volume is transferred
+	// and the transfer is not resumable. The default is 1.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=16
+	//+optional
+	Streams *int32 `json:"streams,omitempty"`
+	// checksum computes the SHA-256 of the source and destination volumes once
+	// the data has been transferred, and reports both in the summary of the
+	// synchronization. With report, differing checksums are only reported.
+	// With enforce, the synchronization fails if they differ. Computing the
+	// checksums reads the whole volume on both ends. The destination must run
+	// a version of VolSync that supports checksums. The default is none.
+	//+kubebuilder:validation:Enum=none;report;enforce
+	//+optional
+	Checksum *string `json:"checksum,omitempty"`
+}
@@ -43,6 +43,21 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeySpec) DeepCopyInto(out *ConfigMapKeySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeySpec.
+func (in *ConfigMapKeySpec) DeepCopy() *ConfigMapKeySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomCASpec) DeepCopyInto(out *CustomCASpec) {
 	*out = *in
@@ -168,6 +183,101 @@ func (in *MoverVolumeSource) DeepCopy() *MoverVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RcloneEncryptionSpec) DeepCopyInto(out *RcloneEncryptionSpec) {
+	*out = *in
+	if in.FilenameEncryption != nil {
+		in, out := &in.FilenameEncryption, &out.FilenameEncryption
+		*out = new(string)
+		**out = **in
+	}
+	if in.DirectoryNameEncryption != nil {
+		in, out := &in.DirectoryNameEncryption, &out.DirectoryNameEncryption
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RcloneEncryptionSpec.
+func (in *RcloneEncryptionSpec) DeepCopy() *RcloneEncryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RcloneEncryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RcloneTuningSpec) DeepCopyInto(out *RcloneTuningSpec) {
+	*out = *in
+	if in.Transfers != nil {
+		in, out := &in.Transfers, &out.Transfers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Checkers != nil {
+		in, out := &in.Checkers, &out.Checkers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BandwidthLimit != nil {
+		in, out := &in.BandwidthLimit, &out.BandwidthLimit
+		*out = new(string)
+		**out = **in
+	}
+	if in.MultiThreadStreams != nil {
+		in, out := &in.MultiThreadStreams, &out.MultiThreadStreams
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FastList != nil {
+		in, out := &in.FastList, &out.FastList
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Checksum != nil {
+		in, out := &in.Checksum, &out.Checksum
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RcloneTuningSpec.
+func (in *RcloneTuningSpec) DeepCopy() *RcloneTuningSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RcloneTuningSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RcloneVersioningSpec) DeepCopyInto(out *RcloneVersioningSpec) {
+	*out = *in
+	if in.Keep != nil {
+		in, out := &in.Keep, &out.Keep
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAgeDays != nil {
+		in, out := &in.MaxAgeDays, &out.MaxAgeDays
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RcloneVersioningSpec.
+func (in *RcloneVersioningSpec) DeepCopy() *RcloneVersioningSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RcloneVersioningSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicationDestination) DeepCopyInto(out *ReplicationDestination) {
 	*out = *in
@@ -257,6 +367,11 @@ func (in *ReplicationDestinationList) DeepCopyObject() runtime.Object {
 func (in *ReplicationDestinationRcloneSpec) DeepCopyInto(out *ReplicationDestinationRcloneSpec) {
 	*out = *in
 	in.ReplicationDestinationVolumeOptions.DeepCopyInto(&out.ReplicationDestinationVolumeOptions)
+	if in.VolumeMode != nil {
+		in, out := &in.VolumeMode, &out.VolumeMode
+		*out = new(v1.PersistentVolumeMode)
+		**out = **in
+	}
 	if in.RcloneConfigSection != nil {
 		in, out := &in.RcloneConfigSection, &out.RcloneConfigSection
 		*out = new(string)
@@ -273,6 +388,46 @@ func (in *ReplicationDestinationRcloneSpec) DeepCopyInto(out *ReplicationDestina
 		**out = **in
 	}
 	out.CustomCA = in.CustomCA
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(RcloneModeType)
+		**out = **in
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FilterConfigMap != nil {
+		in, out := &in.FilterConfigMap, &out.FilterConfigMap
+		*out = new(ConfigMapKeySpec)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(RcloneEncryptionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreVersion != nil {
+		in, out := &in.RestoreVersion, &out.RestoreVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tuning != nil {
+		in, out := &in.Tuning, &out.Tuning
+		*out = new(RcloneTuningSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlockChunkSize != nil {
+		in, out := &in.BlockChunkSize, &out.BlockChunkSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	in.MoverConfig.DeepCopyInto(&out.MoverConfig)
 }
 
@@ -301,10 +456,34 @@ func (in *ReplicationDestinationResticCA) DeepCopy() *ReplicationDestinationRest
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationDestinationResticPreviewStatus) DeepCopyInto(out *ReplicationDestinationResticPreviewStatus) {
+	*out = *in
+	if in.LastPreviewed != nil {
+		in, out := &in.LastPreviewed, &out.LastPreviewed
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationDestinationResticPreviewStatus.
+func (in *ReplicationDestinationResticPreviewStatus) DeepCopy() *ReplicationDestinationResticPreviewStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationDestinationResticPreviewStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicationDestinationResticSpec) DeepCopyInto(out *ReplicationDestinationResticSpec) {
 	*out = *in
 	in.ReplicationDestinationVolumeOptions.DeepCopyInto(&out.ReplicationDestinationVolumeOptions)
+	if in.VolumeMode != nil {
+		in, out := &in.VolumeMode, &out.VolumeMode
+		*out = new(v1.PersistentVolumeMode)
+		**out = **in
+	}
 	out.CustomCA = in.CustomCA
 	if in.CacheCapacity != nil {
 		in, out := &in.CacheCapacity, &out.CacheCapacity
@@ -331,6 +510,11 @@ func (in *ReplicationDestinationResticSpec) DeepCopyInto(out *ReplicationDestina
 		*out = new(string)
 		**out = **in
 	}
+	if in.Tuning != nil {
+		in, out := &in.Tuning, &out.Tuning
+		*out = new(ResticTuningSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.MoverConfig.DeepCopyInto(&out.MoverConfig)
 }
 
@@ -344,6 +528,26 @@ func (in *ReplicationDestinationResticSpec) DeepCopy() *ReplicationDestinationRe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationDestinationResticStatus) DeepCopyInto(out *ReplicationDestinationResticStatus) {
+	*out = *in
+	if in.Preview != nil {
+		in, out := &in.Preview, &out.Preview
+		*out = new(ReplicationDestinationResticPreviewStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationDestinationResticStatus.
+func (in *ReplicationDestinationResticStatus) DeepCopy() *ReplicationDestinationResticStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationDestinationResticStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicationDestinationRsyncSpec) DeepCopyInto(out *ReplicationDestinationRsyncSpec) {
 	*out = *in
@@ -358,6 +562,21 @@ func (in *ReplicationDestinationRsyncSpec) DeepCopyInto(out *ReplicationDestinat
 		*out = new(string)
 		**out = **in
 	}
+	if in.KeyRotationInterval != nil {
+		in, out := &in.KeyRotationInterval, &out.KeyRotationInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.KeyRotationToken != nil {
+		in, out := &in.KeyRotationToken, &out.KeyRotationToken
+		*out = new(string)
+		**out = **in
+	}
+	if in.KeyRotationGracePeriod != nil {
+		in, out := &in.KeyRotationGracePeriod, &out.KeyRotationGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.ServiceType != nil {
 		in, out := &in.ServiceType, &out.ServiceType
 		*out = new(v1.ServiceType)
@@ -441,6 +660,19 @@ func (in *ReplicationDestinationRsyncStatus) DeepCopyInto(out *ReplicationDestin
 		*out = new(int32)
 		**out = **in
 	}
+	if in.LastKeyRotation != nil {
+		in, out := &in.LastKeyRotation, &out.LastKeyRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.KeyRotationToken != nil {
+		in, out := &in.KeyRotationToken, &out.KeyRotationToken
+		*out = new(string)
+		**out = **in
+	}
+	if in.PreviousKeyExpiration != nil {
+		in, out := &in.PreviousKeyExpiration, &out.PreviousKeyExpiration
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationDestinationRsyncStatus.
@@ -467,6 +699,11 @@ func (in *ReplicationDestinationRsyncTLSSpec) DeepCopyInto(out *ReplicationDesti
 		*out = new(string)
 		**out = **in
 	}
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(RsyncTLSCertificateSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ServiceType != nil {
 		in, out := &in.ServiceType, &out.ServiceType
 		*out = new(v1.ServiceType)
@@ -483,6 +720,21 @@ func (in *ReplicationDestinationRsyncTLSSpec) DeepCopyInto(out *ReplicationDesti
 			}
 		}
 	}
+	if in.Persistent != nil {
+		in, out := &in.Persistent, &out.Persistent
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Route != nil {
+		in, out := &in.Route, &out.Route
+		*out = new(RsyncTLSRouteSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSRoute != nil {
+		in, out := &in.TLSRoute, &out.TLSRoute
+		*out = new(RsyncTLSGatewayRouteSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.MoverConfig.DeepCopyInto(&out.MoverConfig)
 }
 
@@ -504,6 +756,11 @@ func (in *ReplicationDestinationRsyncTLSStatus) DeepCopyInto(out *ReplicationDes
 		*out = new(string)
 		**out = **in
 	}
+	if in.LastPSKIdentity != nil {
+		in, out := &in.LastPSKIdentity, &out.LastPSKIdentity
+		*out = new(string)
+		**out = **in
+	}
 	if in.Address != nil {
 		in, out := &in.Address, &out.Address
 		*out = new(string)
@@ -611,6 +868,11 @@ func (in *ReplicationDestinationStatus) DeepCopyInto(out *ReplicationDestination
 		*out = new(ReplicationDestinationRsyncTLSStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Restic != nil {
+		in, out := &in.Restic, &out.Restic
+		*out = new(ReplicationDestinationResticStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.External != nil {
 		in, out := &in.External, &out.External
 		*out = make(map[string]string, len(*in))
@@ -802,6 +1064,46 @@ func (in *ReplicationSourceRcloneSpec) DeepCopyInto(out *ReplicationSourceRclone
 		**out = **in
 	}
 	out.CustomCA = in.CustomCA
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(RcloneModeType)
+		**out = **in
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FilterConfigMap != nil {
+		in, out := &in.FilterConfigMap, &out.FilterConfigMap
+		*out = new(ConfigMapKeySpec)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(RcloneEncryptionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Versioning != nil {
+		in, out := &in.Versioning, &out.Versioning
+		*out = new(RcloneVersioningSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tuning != nil {
+		in, out := &in.Tuning, &out.Tuning
+		*out = new(RcloneTuningSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlockChunkSize != nil {
+		in, out := &in.BlockChunkSize, &out.BlockChunkSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	in.MoverConfig.DeepCopyInto(&out.MoverConfig)
 }
 
@@ -830,6 +1132,25 @@ func (in *ReplicationSourceResticCA) DeepCopy() *ReplicationSourceResticCA {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSourceResticCopyToStatus) DeepCopyInto(out *ReplicationSourceResticCopyToStatus) {
+	*out = *in
+	if in.LastCopied != nil {
+		in, out := &in.LastCopied, &out.LastCopied
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSourceResticCopyToStatus.
+func (in *ReplicationSourceResticCopyToStatus) DeepCopy() *ReplicationSourceResticCopyToStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSourceResticCopyToStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicationSourceResticSpec) DeepCopyInto(out *ReplicationSourceResticSpec) {
 	*out = *in
@@ -860,6 +1181,21 @@ func (in *ReplicationSourceResticSpec) DeepCopyInto(out *ReplicationSourceRestic
 		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
 		copy(*out, *in)
 	}
+	if in.CopyTo != nil {
+		in, out := &in.CopyTo, &out.CopyTo
+		*out = new(ResticCopyToSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(ResticMaintenanceSpec)
+		**out = **in
+	}
+	if in.Tuning != nil {
+		in, out := &in.Tuning, &out.Tuning
+		*out = new(ResticTuningSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.MoverConfig.DeepCopyInto(&out.MoverConfig)
 }
 
@@ -880,6 +1216,24 @@ func (in *ReplicationSourceResticStatus) DeepCopyInto(out *ReplicationSourceRest
 		in, out := &in.LastPruned, &out.LastPruned
 		*out = (*in).DeepCopy()
 	}
+	if in.CopyTo != nil {
+		in, out := &in.CopyTo, &out.CopyTo
+		*out = new(ReplicationSourceResticCopyToStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastChecked != nil {
+		in, out := &in.LastChecked, &out.LastChecked
+		*out = (*in).DeepCopy()
+	}
+	if in.NextMaintenance != nil {
+		in, out := &in.NextMaintenance, &out.NextMaintenance
+		*out = (*in).DeepCopy()
+	}
+	if in.LatestMaintenanceStatus != nil {
+		in, out := &in.LatestMaintenanceStatus, &out.LatestMaintenanceStatus
+		*out = new(MoverStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSourceResticStatus.
@@ -901,6 +1255,21 @@ func (in *ReplicationSourceRsyncSpec) DeepCopyInto(out *ReplicationSourceRsyncSp
 		*out = new(string)
 		**out = **in
 	}
+	if in.KeyRotationInterval != nil {
+		in, out := &in.KeyRotationInterval, &out.KeyRotationInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.KeyRotationToken != nil {
+		in, out := &in.KeyRotationToken, &out.KeyRotationToken
+		*out = new(string)
+		**out = **in
+	}
+	if in.KeyRotationGracePeriod != nil {
+		in, out := &in.KeyRotationGracePeriod, &out.KeyRotationGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.ServiceType != nil {
 		in, out := &in.ServiceType, &out.ServiceType
 		*out = new(v1.ServiceType)
@@ -926,6 +1295,21 @@ func (in *ReplicationSourceRsyncSpec) DeepCopyInto(out *ReplicationSourceRsyncSp
 		*out = new(string)
 		**out = **in
 	}
+	if in.Tuning != nil {
+		in, out := &in.Tuning, &out.Tuning
+		*out = new(RsyncTuningSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludePatterns != nil {
+		in, out := &in.ExcludePatterns, &out.ExcludePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeConfigMap != nil {
+		in, out := &in.ExcludeConfigMap, &out.ExcludeConfigMap
+		*out = new(ConfigMapKeySpec)
+		**out = **in
+	}
 	if in.MoverServiceAccount != nil {
 		in, out := &in.MoverServiceAccount, &out.MoverServiceAccount
 		*out = new(string)
@@ -973,6 +1357,19 @@ func (in *ReplicationSourceRsyncStatus) DeepCopyInto(out *ReplicationSourceRsync
 		*out = new(int32)
 		**out = **in
 	}
+	if in.LastKeyRotation != nil {
+		in, out := &in.LastKeyRotation, &out.LastKeyRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.KeyRotationToken != nil {
+		in, out := &in.KeyRotationToken, &out.KeyRotationToken
+		*out = new(string)
+		**out = **in
+	}
+	if in.PreviousKeyExpiration != nil {
+		in, out := &in.PreviousKeyExpiration, &out.PreviousKeyExpiration
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSourceRsyncStatus.
@@ -994,6 +1391,16 @@ func (in *ReplicationSourceRsyncTLSSpec) DeepCopyInto(out *ReplicationSourceRsyn
 		*out = new(string)
 		**out = **in
 	}
+	if in.PSKIdentity != nil {
+		in, out := &in.PSKIdentity, &out.PSKIdentity
+		*out = new(string)
+		**out = **in
+	}
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(RsyncTLSCertificateSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Address != nil {
 		in, out := &in.Address, &out.Address
 		*out = new(string)
@@ -1004,6 +1411,33 @@ func (in *ReplicationSourceRsyncTLSSpec) DeepCopyInto(out *ReplicationSourceRsyn
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Tuning != nil {
+		in, out := &in.Tuning, &out.Tuning
+		*out = new(RsyncTuningSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludePatterns != nil {
+		in, out := &in.ExcludePatterns, &out.ExcludePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeConfigMap != nil {
+		in, out := &in.ExcludeConfigMap, &out.ExcludeConfigMap
+		*out = new(ConfigMapKeySpec)
+		**out = **in
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]RsyncTLSTargetSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockTransfer != nil {
+		in, out := &in.BlockTransfer, &out.BlockTransfer
+		*out = new(RsyncTLSBlockTransferSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.MoverConfig.DeepCopyInto(&out.MoverConfig)
 }
 
@@ -1025,6 +1459,23 @@ func (in *ReplicationSourceRsyncTLSStatus) DeepCopyInto(out *ReplicationSourceRs
 		*out = new(string)
 		**out = **in
 	}
+	if in.LastPSKIdentity != nil {
+		in, out := &in.LastPSKIdentity, &out.LastPSKIdentity
+		*out = new(string)
+		**out = **in
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]RsyncTLSTargetStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BaseSnapshot != nil {
+		in, out := &in.BaseSnapshot, &out.BaseSnapshot
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSourceRsyncTLSStatus.
@@ -1186,6 +1637,16 @@ func (in *ReplicationSourceSyncthingSpec) DeepCopyInto(out *ReplicationSourceSyn
 		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
 		copy(*out, *in)
 	}
+	if in.IgnorePatterns != nil {
+		in, out := &in.IgnorePatterns, &out.IgnorePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoreConfigMap != nil {
+		in, out := &in.IgnoreConfigMap, &out.IgnoreConfigMap
+		*out = new(ConfigMapKeySpec)
+		**out = **in
+	}
 	in.MoverConfig.DeepCopyInto(&out.MoverConfig)
 }
 
@@ -1207,6 +1668,11 @@ func (in *ReplicationSourceSyncthingStatus) DeepCopyInto(out *ReplicationSourceS
 		*out = make([]SyncthingPeerStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.IgnorePatterns != nil {
+		in, out := &in.IgnorePatterns, &out.IgnorePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSourceSyncthingStatus.
@@ -1274,6 +1740,41 @@ func (in *ReplicationSourceVolumeOptions) DeepCopy() *ReplicationSourceVolumeOpt
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticCopyToSpec) DeepCopyInto(out *ResticCopyToSpec) {
+	*out = *in
+	if in.Retain != nil {
+		in, out := &in.Retain, &out.Retain
+		*out = new(ResticRetainPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticCopyToSpec.
+func (in *ResticCopyToSpec) DeepCopy() *ResticCopyToSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticCopyToSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticMaintenanceSpec) DeepCopyInto(out *ResticMaintenanceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticMaintenanceSpec.
+func (in *ResticMaintenanceSpec) DeepCopy() *ResticMaintenanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticMaintenanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResticRetainPolicy) DeepCopyInto(out *ResticRetainPolicy) {
 	*out = *in
@@ -1324,6 +1825,280 @@ func (in *ResticRetainPolicy) DeepCopy() *ResticRetainPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticTuningSpec) DeepCopyInto(out *ResticTuningSpec) {
+	*out = *in
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(string)
+		**out = **in
+	}
+	if in.PackSizeMiB != nil {
+		in, out := &in.PackSizeMiB, &out.PackSizeMiB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LimitUploadKiB != nil {
+		in, out := &in.LimitUploadKiB, &out.LimitUploadKiB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LimitDownloadKiB != nil {
+		in, out := &in.LimitDownloadKiB, &out.LimitDownloadKiB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReadConcurrency != nil {
+		in, out := &in.ReadConcurrency, &out.ReadConcurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.S3Connections != nil {
+		in, out := &in.S3Connections, &out.S3Connections
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticTuningSpec.
+func (in *ResticTuningSpec) DeepCopy() *ResticTuningSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticTuningSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RsyncTLSBlockTransferSpec) DeepCopyInto(out *RsyncTLSBlockTransferSpec) {
+	*out = *in
+	if in.Resumable != nil {
+		in, out := &in.Resumable, &out.Resumable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ChangedBlocks != nil {
+		in, out := &in.ChangedBlocks, &out.ChangedBlocks
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(string)
+		**out = **in
+	}
+	if in.CompressionLevel != nil {
+		in, out := &in.CompressionLevel, &out.CompressionLevel
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Streams != nil {
+		in, out := &in.Streams, &out.Streams
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Checksum != nil {
+		in, out := &in.Checksum, &out.Checksum
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RsyncTLSBlockTransferSpec.
+func (in *RsyncTLSBlockTransferSpec) DeepCopy() *RsyncTLSBlockTransferSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RsyncTLSBlockTransferSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RsyncTLSCertificateSpec) DeepCopyInto(out *RsyncTLSCertificateSpec) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(CustomCASpec)
+		**out = **in
+	}
+	if in.PeerName != nil {
+		in, out := &in.PeerName, &out.PeerName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RsyncTLSCertificateSpec.
+func (in *RsyncTLSCertificateSpec) DeepCopy() *RsyncTLSCertificateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RsyncTLSCertificateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RsyncTLSGatewayRouteSpec) DeepCopyInto(out *RsyncTLSGatewayRouteSpec) {
+	*out = *in
+	if in.GatewayNamespace != nil {
+		in, out := &in.GatewayNamespace, &out.GatewayNamespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.SectionName != nil {
+		in, out := &in.SectionName, &out.SectionName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RsyncTLSGatewayRouteSpec.
+func (in *RsyncTLSGatewayRouteSpec) DeepCopy() *RsyncTLSGatewayRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RsyncTLSGatewayRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RsyncTLSRouteSpec) DeepCopyInto(out *RsyncTLSRouteSpec) {
+	*out = *in
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RsyncTLSRouteSpec.
+func (in *RsyncTLSRouteSpec) DeepCopy() *RsyncTLSRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RsyncTLSRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RsyncTLSTargetSpec) DeepCopyInto(out *RsyncTLSTargetSpec) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeySecret != nil {
+		in, out := &in.KeySecret, &out.KeySecret
+		*out = new(string)
+		**out = **in
+	}
+	if in.PSKIdentity != nil {
+		in, out := &in.PSKIdentity, &out.PSKIdentity
+		*out = new(string)
+		**out = **in
+	}
+	if in.PeerName != nil {
+		in, out := &in.PeerName, &out.PeerName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RsyncTLSTargetSpec.
+func (in *RsyncTLSTargetSpec) DeepCopy() *RsyncTLSTargetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RsyncTLSTargetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RsyncTLSTargetStatus) DeepCopyInto(out *RsyncTLSTargetStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSyncDuration != nil {
+		in, out := &in.LastSyncDuration, &out.LastSyncDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.LastPSKIdentity != nil {
+		in, out := &in.LastPSKIdentity, &out.LastPSKIdentity
+		*out = new(string)
+		**out = **in
+	}
+	if in.LatestMoverStatus != nil {
+		in, out := &in.LatestMoverStatus, &out.LatestMoverStatus
+		*out = new(MoverStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RsyncTLSTargetStatus.
+func (in *RsyncTLSTargetStatus) DeepCopy() *RsyncTLSTargetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RsyncTLSTargetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RsyncTuningSpec) DeepCopyInto(out *RsyncTuningSpec) {
+	*out = *in
+	if in.BandwidthLimitKiB != nil {
+		in, out := &in.BandwidthLimitKiB, &out.BandwidthLimitKiB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CompressionLevel != nil {
+		in, out := &in.CompressionLevel, &out.CompressionLevel
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Checksum != nil {
+		in, out := &in.Checksum, &out.Checksum
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Partial != nil {
+		in, out := &in.Partial, &out.Partial
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RsyncTuningSpec.
+func (in *RsyncTuningSpec) DeepCopy() *RsyncTuningSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RsyncTuningSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncthingPeer) DeepCopyInto(out *SyncthingPeer) {
 	*out = *in
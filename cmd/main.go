@@ -38,10 +38,12 @@ import (
 	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
 	volumepopulatorv1beta1 "github.com/kubernetes-csi/volume-data-source-validator/client/apis/volumepopulator/v1beta1"
 	ocpconfigv1 "github.com/openshift/api/config/v1"
+	ocproutev1 "github.com/openshift/api/route/v1"
 	ocpsecurityv1 "github.com/openshift/api/security/v1"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"go.uber.org/zap/zapcore"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	kruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -82,6 +84,7 @@ func init() {
 	utilruntime.Must(ocpsecurityv1.AddToScheme(scheme))
 	utilruntime.Must(volumepopulatorv1beta1.AddToScheme(scheme))
 	utilruntime.Must(ocpconfigv1.AddToScheme(scheme))
+	utilruntime.Must(ocproutev1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -353,6 +356,14 @@ func main() {
 		LeaseDuration:                 &leaseDuration,
 		RenewDeadline:                 &renewDeadline,
 		RetryPeriod:                   &retryPeriod,
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				// The restic maintenance Leases are only in the operator
+				// namespace and must be read from the API server to
+				// coordinate maintenance
+				DisableFor: []client.Object{&coordinationv1.Lease{}},
+			},
+		},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -0,0 +1,115 @@
+/*
+Copyright © 2026 The VolSync authors
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-logr/logr"
+	"github.com/klauspost/compress/zstd"
+)
+
+// A compressed connection starts with zstdMagic, sent uncompressed. Everything
+// that follows in both directions is a zstd stream, starting with the magic of
+// the transfer mode, or the diskrsync header. Each write is flushed so that the request and reply
+// exchanges of diskrsync don't wait on buffered data.
+const (
+	zstdMagic = "VSZSTD01"
+
+	compressionNone = "none"
+	compressionZstd = "zstd"
+
+	defaultCompressionLevel = 3
+)
+
+// compressedConn compresses the data written to and decompresses the data read
+// from a connection
+type compressedConn struct {
+	io.Reader
+	enc *zstd.Encoder
+}
+
+func newCompressedConn(conn io.ReadWriter, level int) (*compressedConn, error) {
+	enc, err := zstd.NewWriter(conn, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)),
+		zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	// A single goroutine decodes synchronously, without reading ahead of the
+	// data that has been flushed by the peer
+	dec, err := zstd.NewReader(conn, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return &compressedConn{Reader: dec, enc: enc}, nil
+}
+
+func (c *compressedConn) Write(p []byte) (int, error) {
+	n, err := c.enc.Write(p)
+	if err != nil {
+		return n, err
+	}
+	return n, c.enc.Flush()
+}
+
+// endStream ends the compressed stream, so that a target reading until the end of
+// the stream gets all of the data before the connection is closed
+func endStream(rw io.ReadWriter) error {
+	if c, ok := rw.(*compressedConn); ok {
+		return c.enc.Close()
+	}
+	return nil
+}
+
+// compressConn starts compression on the source side of a connection
+func compressConn(conn io.ReadWriter, opts *options) (io.ReadWriter, error) {
+	switch opts.compression {
+	case "", compressionNone:
+		return conn, nil
+	case compressionZstd:
+		if _, err := conn.Write([]byte(zstdMagic)); err != nil {
+			return nil, err
+		}
+		return newCompressedConn(conn, opts.compressionLevel)
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", opts.compression)
+	}
+}
+
+// readMagic reads the magic that starts a transfer on the target side of a
+// connection, starting the decompression first if the source compresses the
+// connection. The magics of the transfer modes and the diskrsync header have the
+// same length.
+func readMagic(conn io.ReadWriter, logger logr.Logger) (io.ReadWriter, []byte, error) {
+	magic := make([]byte, len(zstdMagic))
+	if _, err := io.ReadFull(conn, magic); err != nil {
+		return nil, nil, err
+	}
+	if string(magic) != zstdMagic {
+		return conn, magic, nil
+	}
+	logger.V(1).Info("Compressed connection", "compression", compressionZstd)
+	rw, err := newCompressedConn(conn, defaultCompressionLevel)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := io.ReadFull(rw, magic); err != nil {
+		return nil, nil, err
+	}
+	return rw, magic, nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright © 2026 The VolSync authors
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/dop251/diskrsync"
+	"github.com/dop251/spgz"
+	"github.com/go-logr/logr"
+)
+
+// A delta transfer sends only the ranges of the device that changed since the
+// base snapshot, as reported by the CSI SnapshotMetadata service. It relies on
+// the target holding the data of the base snapshot, which is the case when the
+// previous sync completed.
+//
+// Protocol, after the connection is established:
+//
+//	source -> target: deltaMagic, size (uint64)
+//	target -> source: deltaApply or deltaFull
+//	  deltaFull: sizes differ, a single diskrsync of the whole device follows
+//	  deltaApply:
+//	    for each range: source -> target: offset (uint64), length (uint64), data
+//	    source -> target: deltaEnd
+//	    target -> source: deltaAck, once the data is on disk
+const (
+	deltaMagic = "VSDELTA1"
+
+	deltaApply = byte(0)
+	deltaFull  = byte(1)
+	deltaAck   = byte(2)
+
+	deltaEnd = int64(-1)
+)
+
+// deltaSource sends the changed ranges of the source to the target
+func deltaSource(src io.ReaderAt, size int64, ranges []blockRange, conn io.ReadWriter,
+	opts *options, logger logr.Logger) error {
+	hello := bytes.NewBufferString(deltaMagic)
+	_ = writeUint64(hello, size)
+	if _, err := conn.Write(hello.Bytes()); err != nil {
+		return err
+	}
+
+	mode := make([]byte, 1)
+	if _, err := io.ReadFull(conn, mode); err != nil {
+		return fmt.Errorf("could not read delta mode: %w", err)
+	}
+	if mode[0] == deltaFull {
+		logger.Info("Target size differs, transferring the whole device")
+		return diskrsync.Source(io.NewSectionReader(src, 0, size), size, conn, conn, true, opts.verbose,
+			newProgress("calc progress", logger), newProgress("sync progress", logger))
+	}
+
+	sent := int64(0)
+	p := newProgress("sync progress", logger)
+	p.Start(rangesLength(ranges))
+	for _, r := range ranges {
+		if r.offset >= size {
+			continue
+		}
+		length := min(r.length, size-r.offset)
+		header := &bytes.Buffer{}
+		_ = writeUint64(header, r.offset)
+		_ = writeUint64(header, length)
+		if _, err := conn.Write(header.Bytes()); err != nil {
+			return err
+		}
+		if _, err := io.Copy(conn, io.NewSectionReader(src, r.offset, length)); err != nil {
+			return err
+		}
+		sent += length
+		p.Update(sent)
+	}
+	if err := writeUint64(conn, deltaEnd); err != nil {
+		return err
+	}
+
+	ack := make([]byte, 1)
+	if _, err := io.ReadFull(conn, ack); err != nil {
+		return fmt.Errorf("could not read delta acknowledgement: %w", err)
+	}
+	if ack[0] != deltaAck {
+		return fmt.Errorf("unexpected delta acknowledgement: %d", ack[0])
+	}
+	logger.Info("Changed blocks transferred", "bytes", sent, "total bytes", size)
+	return nil
+}
+
+// deltaTarget writes the changed ranges received from the source, after the
+// deltaMagic has been read from the connection
+func deltaTarget(w spgz.SparseFile, size int64, conn io.ReadWriter, useReadBuffer bool,
+	opts *options, logger logr.Logger) error {
+	sourceSize, err := readUint64(conn)
+	if err != nil {
+		return err
+	}
+	if sourceSize != size {
+		logger.Info("Source size differs, transferring the whole device", "source size", sourceSize)
+		if _, err := conn.Write([]byte{deltaFull}); err != nil {
+			return err
+		}
+		return diskrsync.Target(w, size, conn, conn, useReadBuffer, opts.verbose,
+			newProgress("calc progress", logger), newProgress("sync progress", logger))
+	}
+	if _, err := conn.Write([]byte{deltaApply}); err != nil {
+		return err
+	}
+
+	received := int64(0)
+	for {
+		offset, err := readUint64(conn)
+		if err != nil {
+			return err
+		}
+		if offset == deltaEnd {
+			break
+		}
+		length, err := readUint64(conn)
+		if err != nil {
+			return err
+		}
+		if offset < 0 || length < 0 || offset+length > size {
+			return fmt.Errorf("invalid range %d+%d for size %d", offset, length, size)
+		}
+		if _, err := io.CopyN(io.NewOffsetWriter(w, offset), conn, length); err != nil {
+			return err
+		}
+		received += length
+	}
+
+	if err := w.Sync(); err != nil {
+		return err
+	}
+	if _, err := conn.Write([]byte{deltaAck}); err != nil {
+		return err
+	}
+	logger.Info("Changed blocks transferred", "bytes", received, "total bytes", size)
+	return nil
+}
+
+func rangesLength(ranges []blockRange) int64 {
+	total := int64(0)
+	for _, r := range ranges {
+		total += r.length
+	}
+	return total
+}
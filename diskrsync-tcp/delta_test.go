@@ -0,0 +1,219 @@
+/*
+Copyright © 2026 The VolSync authors
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dop251/spgz"
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// fakeSnapshotMetadata serves GetMetadataDelta from a fixed list of blocks, in
+// responses of at most two blocks
+type fakeSnapshotMetadata struct {
+	token    string
+	capacity int64
+	blocks   []blockRange
+	request  *dynamicpb.Message
+}
+
+func (f *fakeSnapshotMetadata) getMetadataDelta(_ interface{}, stream grpc.ServerStream) error {
+	messages, err := newSnapshotMetadataMessages()
+	if err != nil {
+		return err
+	}
+	f.request = dynamicpb.NewMessage(messages.request)
+	if err := stream.RecvMsg(f.request); err != nil {
+		return err
+	}
+	if getField(f.request, "security_token").String() != f.token {
+		return status.Error(codes.Unauthenticated, "invalid token")
+	}
+	for i := 0; i < len(f.blocks); i += 2 {
+		response := dynamicpb.NewMessage(messages.response)
+		setField(response, "block_metadata_type", protoreflect.ValueOfEnum(2))
+		setField(response, "volume_capacity_bytes", protoreflect.ValueOfInt64(f.capacity))
+		list := response.Mutable(messages.response.Fields().ByName("block_metadata")).List()
+		for _, b := range f.blocks[i:min(i+2, len(f.blocks))] {
+			block := dynamicpb.NewMessage(messages.block)
+			setField(block, "byte_offset", protoreflect.ValueOfInt64(b.offset))
+			setField(block, "size_bytes", protoreflect.ValueOfInt64(b.length))
+			list.Append(protoreflect.ValueOfMessage(block))
+		}
+		if err := stream.SendMsg(response); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startFakeSnapshotMetadata starts the fake service and returns its address
+func startFakeSnapshotMetadata(t *testing.T, fake *fakeSnapshotMetadata) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: snapshotMetadataService,
+		HandlerType: (*interface{})(nil),
+		Streams: []grpc.StreamDesc{{
+			StreamName:    "GetMetadataDelta",
+			Handler:       fake.getMetadataDelta,
+			ServerStreams: true,
+		}},
+	}, fake)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func TestChangedBlocks(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeSnapshotMetadata{
+		token:    "secret",
+		capacity: testSize,
+		blocks: []blockRange{
+			{offset: 3 * testSegmentSize, length: 4096},
+			{offset: 0, length: 4096},
+			{offset: 4096, length: 8192},
+		},
+	}
+	opts := &snapshotMetadataOptions{
+		address:        startFakeSnapshotMetadata(t, fake),
+		tokenFile:      tokenFile,
+		namespace:      "ns",
+		baseSnapshotID: "snap-1",
+		targetSnapshot: "volsync-src",
+	}
+
+	ranges, err := changedBlocks(testSize, opts, logr.Discard())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []blockRange{{offset: 0, length: 12288}, {offset: 3 * testSegmentSize, length: 4096}}
+	if len(ranges) != len(expected) || ranges[0] != expected[0] || ranges[1] != expected[1] {
+		t.Fatalf("unexpected ranges %v", ranges)
+	}
+	if getField(fake.request, "base_snapshot_id").String() != "snap-1" ||
+		getField(fake.request, "target_snapshot_name").String() != "volsync-src" ||
+		getField(fake.request, "namespace").String() != "ns" {
+		t.Fatalf("unexpected request %v", fake.request)
+	}
+
+	// A device that doesn't match the snapshot can't be transferred by delta
+	if _, err := changedBlocks(testSize+1, opts, logr.Discard()); err == nil {
+		t.Fatal("expected a capacity mismatch error")
+	}
+
+	// Authentication failures are reported so the source falls back to a full
+	// transfer
+	opts.tokenFile = ""
+	if _, err := changedBlocks(testSize, opts, logr.Discard()); err == nil {
+		t.Fatal("expected an authentication error")
+	}
+}
+
+func TestDeltaTransfer(t *testing.T) {
+	dir := t.TempDir()
+	base := make([]byte, testSize)
+	if _, err := rand.Read(base); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Clone(base)
+	changed := []blockRange{{offset: 4096, length: 8192}, {offset: testSize - 1000, length: 1000}}
+	for _, r := range changed {
+		if _, err := rand.Read(data[r.offset : r.offset+r.length]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	src := writeTestFile(t, filepath.Join(dir, "src"), data)
+	dst := writeTestFile(t, filepath.Join(dir, "dst"), base)
+
+	srcConn, dstConn := connPair(t)
+	var dstErr error
+	done := make(chan struct{})
+	go func() {
+		_, dstErr = receive(spgz.NewSparseFileWithFallback(dst), testSize, dstConn, nil, false, &options{},
+			logr.Discard())
+		dstConn.Close()
+		close(done)
+	}()
+	srcErr := deltaSource(src, testSize, changed, srcConn, &options{}, logr.Discard())
+	srcConn.Close()
+	<-done
+	if srcErr != nil || dstErr != nil {
+		t.Fatalf("transfer failed: %v, %v", srcErr, dstErr)
+	}
+	result, err := os.ReadFile(dst.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result, data) {
+		t.Fatal("target does not match the source")
+	}
+}
+
+func TestDeltaTransferSizeMismatch(t *testing.T) {
+	dir := t.TempDir()
+	data := make([]byte, testSize)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	src := writeTestFile(t, filepath.Join(dir, "src"), data)
+	dst := writeTestFile(t, filepath.Join(dir, "dst"), make([]byte, testSize-4096))
+
+	srcConn, dstConn := connPair(t)
+	var dstErr error
+	done := make(chan struct{})
+	go func() {
+		_, dstErr = receive(spgz.NewSparseFileWithFallback(dst), testSize-4096, dstConn, nil, false, &options{},
+			logr.Discard())
+		dstConn.Close()
+		close(done)
+	}()
+	// The target falls back to a full transfer, which sends all of the data
+	srcErr := deltaSource(src, testSize, []blockRange{}, srcConn, &options{}, logr.Discard())
+	srcConn.Close()
+	<-done
+	if srcErr != nil || dstErr != nil {
+		t.Fatalf("transfer failed: %v, %v", srcErr, dstErr)
+	}
+	result, err := os.ReadFile(dst.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result, data) {
+		t.Fatal("target does not match the source")
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright © 2026 The VolSync authors
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// The source tells the target which PSK identity it connected with, so that
+// the destination of a block volume can report the key in use. It is only sent
+// when set, for compatibility with older targets.
+//
+// Protocol:
+//
+//	on the first connection of the transfer, before compression and the
+//	checksum request:
+//	  source -> target: identityMagic, length (uint64), identity
+const (
+	identityMagic = "VSIDENT1"
+
+	// stunnel limits PSK identities to 128 bytes
+	maxIdentityLength = 128
+)
+
+// sendIdentity sends the PSK identity, if any, to the target
+func sendIdentity(w io.Writer, identity string) error {
+	if identity == "" {
+		return nil
+	}
+	if len(identity) > maxIdentityLength {
+		return fmt.Errorf("PSK identity is longer than %d bytes", maxIdentityLength)
+	}
+	header := bytes.NewBufferString(identityMagic)
+	_ = writeUint64(header, int64(len(identity)))
+	header.WriteString(identity)
+	_, err := w.Write(header.Bytes())
+	return err
+}
+
+// readIdentity reads the PSK identity sent by the source, after the
+// identityMagic
+func readIdentity(r io.Reader) (string, error) {
+	length, err := readUint64(r)
+	if err != nil {
+		return "", err
+	}
+	if length < 0 || length > maxIdentityLength {
+		return "", fmt.Errorf("invalid PSK identity length %d", length)
+	}
+	identity := make([]byte, length)
+	if _, err := io.ReadFull(r, identity); err != nil {
+		return "", err
+	}
+	return string(identity), nil
+}
+
+// writeIdentityFile records the PSK identity used by the source once the sync
+// has completed
+func writeIdentityFile(fileName, identity string) error {
+	if fileName == "" || identity == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, []byte(identity), 0644)
+}
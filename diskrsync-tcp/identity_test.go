@@ -0,0 +1,100 @@
+/*
+Copyright © 2026 The VolSync authors
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dop251/spgz"
+	"github.com/go-logr/logr"
+)
+
+func TestIdentity(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := sendIdentity(buf, "volsync-abc"); err != nil {
+		t.Fatal(err)
+	}
+	if magic := string(buf.Next(len(identityMagic))); magic != identityMagic {
+		t.Fatalf("unexpected magic %q", magic)
+	}
+	if identity, err := readIdentity(buf); err != nil || identity != "volsync-abc" {
+		t.Fatalf("unexpected identity %q: %v", identity, err)
+	}
+
+	// Nothing is sent without an identity, for compatibility with older
+	// targets
+	buf.Reset()
+	if err := sendIdentity(buf, ""); err != nil || buf.Len() != 0 {
+		t.Fatalf("unexpected identity %q: %v", buf.String(), err)
+	}
+	if err := sendIdentity(buf, strings.Repeat("a", maxIdentityLength+1)); err == nil {
+		t.Fatal("expected a long identity to be rejected")
+	}
+}
+
+func TestIdentityFile(t *testing.T) {
+	for _, streams := range []int{1, 3} {
+		srcName, dst, data := checksumTestFiles(t)
+		identityFile := filepath.Join(t.TempDir(), "control", "psk-identity")
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		done := make(chan error)
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				done <- err
+				return
+			}
+			defer conn.Close()
+			_, err = receive(spgz.NewSparseFileWithFallback(dst), testSize, conn, listener.Accept, false,
+				&options{pskIdentityFile: identityFile}, logr.Discard())
+			done <- err
+		}()
+
+		dial := func() (net.Conn, error) { return net.Dial("tcp", listener.Addr().String()) }
+		opts := &options{checksum: checksumReport, streams: streams, pskIdentity: "volsync-abc"}
+		_, srcErr := syncToTarget(srcName, dial, opts, logr.Discard())
+		dstErr := <-done
+		listener.Close()
+		if srcErr != nil || dstErr != nil {
+			t.Fatalf("transfer with %d streams failed: %v, %v", streams, srcErr, dstErr)
+		}
+
+		result, err := os.ReadFile(dst.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(result, data) {
+			t.Fatal("target does not match the source")
+		}
+		identity, err := os.ReadFile(identityFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(identity) != "volsync-abc" {
+			t.Fatalf("unexpected identity %q", identity)
+		}
+	}
+}
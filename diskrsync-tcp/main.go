@@ -17,6 +17,8 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package main
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"flag"
 	"fmt"
@@ -24,6 +26,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"go.uber.org/zap/zapcore"
@@ -38,8 +41,29 @@ import (
 var volsyncVersion = "0.0.0"
 
 type options struct {
-	noCompress bool
-	verbose    bool
+	noCompress  bool
+	verbose     bool
+	resume      bool
+	segmentSize int64
+	stateFile   string
+	metadata    snapshotMetadataOptions
+	// Compression of the data sent over the connections
+	compression      string
+	compressionLevel int
+	streams          int
+	// Verification of the device checksums at the end of the sync
+	checksum string
+	// PSK identity sent by the source, and where the target records it
+	pskIdentity     string
+	pskIdentityFile string
+}
+
+// sourceReader is the data to transfer, either a raw file or device, or an spgz
+// file
+type sourceReader interface {
+	io.ReadSeeker
+	io.ReaderAt
+	io.Closer
 }
 
 func usage() {
@@ -60,6 +84,36 @@ func main() {
 
 	flag.BoolVar(&opts.noCompress, "no-compress", false, "Store target as a raw file")
 	flag.BoolVar(&opts.verbose, "verbose", true, "Print statistics, progress, and some debug info")
+	flag.BoolVar(&opts.resume, "resume", false,
+		"Transfer in segments that are checkpointed by the target so an interrupted transfer can resume, source only")
+	flag.Int64Var(&opts.segmentSize, "segment-size", defaultSegmentSize,
+		"size of the segments of a resumable transfer, source only")
+	flag.StringVar(&opts.stateFile, "state-file", "",
+		"name and path of the file that records the progress of resumable transfers, target only")
+	flag.StringVar(&opts.metadata.address, "snapshot-metadata-address", "",
+		"address of the CSI SnapshotMetadata service used to find the changed blocks, source only")
+	flag.StringVar(&opts.metadata.caFile, "snapshot-metadata-ca-file", "",
+		"CA certificate of the SnapshotMetadata service, source only")
+	flag.StringVar(&opts.metadata.tokenFile, "snapshot-metadata-token-file", "",
+		"file containing the token to authenticate with the SnapshotMetadata service, source only")
+	flag.StringVar(&opts.metadata.namespace, "namespace", "", "namespace of the snapshots, source only")
+	flag.StringVar(&opts.metadata.baseSnapshotID, "base-snapshot-id", "",
+		"CSI snapshot handle of the snapshot the target already holds, source only")
+	flag.StringVar(&opts.metadata.targetSnapshot, "target-snapshot", "",
+		"name of the VolumeSnapshot being transferred, source only")
+	flag.StringVar(&opts.compression, "compression", compressionNone,
+		"compression of the data sent to the target, none or zstd, source only")
+	flag.IntVar(&opts.compressionLevel, "compression-level", defaultCompressionLevel,
+		"zstd compression level from 1 to 22, source only")
+	flag.IntVar(&opts.streams, "streams", 1,
+		"number of parts of the device transferred over parallel connections, source only")
+	flag.StringVar(&opts.checksum, "checksum", checksumNone,
+		"compare the SHA-256 of the devices at the end of the sync, none, report or enforce to fail "+
+			"the sync if they differ, source only")
+	flag.StringVar(&opts.pskIdentity, "psk-identity", "",
+		"PSK identity the connection was made with, reported to the target, source only")
+	flag.StringVar(&opts.pskIdentityFile, "psk-identity-file", "",
+		"name and path of the file to write the PSK identity reported by the source to, target only")
 
 	zapopts := zap.Options{
 		Development: true,
@@ -82,6 +136,31 @@ func main() {
 			usage()
 			os.Exit(1)
 		}
+		if opts.segmentSize <= 0 || opts.segmentSize%diskrsync.DefTargetBlockSize != 0 {
+			fmt.Fprintf(os.Stderr, "segment-size must be a multiple of %d\n", diskrsync.DefTargetBlockSize)
+			usage()
+			os.Exit(1)
+		}
+		if opts.compression != compressionNone && opts.compression != compressionZstd {
+			fmt.Fprintf(os.Stderr, "compression must be %s or %s\n", compressionNone, compressionZstd)
+			usage()
+			os.Exit(1)
+		}
+		if opts.compressionLevel < 1 || opts.compressionLevel > 22 {
+			fmt.Fprintf(os.Stderr, "compression-level must be between 1 and 22\n")
+			usage()
+			os.Exit(1)
+		}
+		if opts.streams < 1 {
+			fmt.Fprintf(os.Stderr, "streams must be at least 1\n")
+			usage()
+			os.Exit(1)
+		}
+		if !slices.Contains(checksumModes, opts.checksum) {
+			fmt.Fprintf(os.Stderr, "checksum must be %s, %s or %s\n", checksumNone, checksumReport, checksumEnforce)
+			usage()
+			os.Exit(1)
+		}
 		if err := connectToTarget(os.Args[1], *targetAddress, *port, &opts, logger); err != nil {
 			logger.Error(err, "Unable to connect to target", "source file", os.Args[1], "target address", *targetAddress)
 			os.Exit(1)
@@ -118,52 +197,147 @@ func createControlFile(fileName string) error {
 	return err
 }
 
-func connectToTarget(sourceFile, targetAddress string, port int, opts *options, logger logr.Logger) error {
-	f, err := os.Open(sourceFile)
+func openSource(fileName string, logger logr.Logger) (sourceReader, error) {
+	f, err := os.Open(fileName)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	logger.Info("Opened filed", "file", sourceFile)
-	defer f.Close()
-	var src io.ReadSeeker
+	logger.Info("Opened filed", "file", fileName)
 
 	// Try to open as an spgz file
 	sf, err := spgz.NewFromFile(f, os.O_RDONLY)
 	if err != nil {
 		if !errors.Is(err, spgz.ErrInvalidFormat) {
-			return err
+			f.Close()
+			return nil, err
 		}
 		logger.Info("Not an spgz file")
-		src = f
-	} else {
-		logger.Info("spgz file")
-		src = sf
+		return f, nil
 	}
+	logger.Info("spgz file")
+	return sf, nil
+}
+
+func connectToTarget(sourceFile, targetAddress string, port int, opts *options, logger logr.Logger) error {
+	dial := func() (net.Conn, error) {
+		return net.Dial("tcp", net.JoinHostPort(targetAddress, fmt.Sprintf("%d", port)))
+	}
+	_, err := syncToTarget(sourceFile, dial, opts, logger)
+	return err
+}
+
+// syncToTarget transfers the source to the target reached by dial and returns
+// the summary of the sync
+//
+//nolint:funlen
+func syncToTarget(sourceFile string, dial func() (net.Conn, error), opts *options,
+	logger logr.Logger) (*syncSummary, error) {
+	stats := newTransferStats()
+	src, err := openSource(sourceFile, logger)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
 
 	size, err := src.Seek(0, io.SeekEnd)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	_, err = src.Seek(0, io.SeekStart)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// Find the changed blocks before connecting, so that a failure can fall
+	// back to a full transfer
+	var ranges []blockRange
+	if opts.metadata.enabled() {
+		ranges, err = changedBlocks(size, &opts.metadata, logger)
+		if err != nil {
+			logger.Error(err, "Unable to get the changed blocks, transferring the whole device")
+		}
+	}
+
+	countingDial := func() (net.Conn, error) {
+		conn, err := dial()
+		if err != nil {
+			return nil, err
+		}
+		return &countingConn{Conn: conn, n: &stats.sent}, nil
+	}
+	open := func() (sourceReader, error) {
+		r, err := openSource(sourceFile, logger)
+		if err != nil {
+			return nil, err
+		}
+		return &countingSource{sourceReader: r, n: &stats.read}, nil
+	}
+	logger.Info("source", "size", size)
+	if ranges == nil && !opts.resume && opts.streams > 1 {
+		// Resumable and changed blocks transfers use a single connection
+		err = parallelSource(open, size, countingDial, opts, logger)
+	} else {
+		err = sendDevice(&countingSource{sourceReader: src, n: &stats.read}, open, size, ranges, countingDial,
+			opts, logger)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var sourceSum, targetSum string
+	var blocksChanged int64
+	if checksumEnabled(opts.checksum) {
+		sourceSum, targetSum, blocksChanged, err = verifySource(src, size, countingDial, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+	summary := stats.summary()
+	if checksumEnabled(opts.checksum) {
+		summary.BlocksChanged = &blocksChanged
+		summary.SourceSHA256 = sourceSum
+		summary.TargetSHA256 = targetSum
 	}
+	return summary, summary.finish(opts.checksum, logger)
+}
 
-	conn, err := net.Dial("tcp", net.JoinHostPort(targetAddress, fmt.Sprintf("%d", port)))
+// sendDevice transfers the source over a single connection
+func sendDevice(src sourceReader, open func() (sourceReader, error), size int64, ranges []blockRange,
+	dial func() (net.Conn, error), opts *options, logger logr.Logger) error {
+	conn, err := dial()
 	if err != nil {
 		return err
 	}
-	logger.Info("source", "size", size)
-	calcProgress := &progress{
-		progressType: "calc progress",
-		logger:       logger,
+	defer conn.Close()
+	if err := sendIdentity(conn, opts.pskIdentity); err != nil {
+		return err
 	}
-	syncProgress := &progress{
-		progressType: "sync progress",
-		logger:       logger,
+	if err := requestChecksum(conn, opts.checksum); err != nil {
+		return err
+	}
+	rw, err := compressConn(conn, opts)
+	if err != nil {
+		return err
+	}
+	if ranges != nil {
+		err = deltaSource(src, size, ranges, rw, opts, logger)
+	} else if opts.resume {
+		// Segments are hashed while they are transferred, using a separate
+		// handle
+		var hashSrc sourceReader
+		hashSrc, err = open()
+		if err == nil {
+			err = resumeSource(src, hashSrc, size, opts.segmentSize, rw, opts, logger)
+			hashSrc.Close()
+		}
+	} else {
+		err = diskrsync.Source(src, size, rw, rw, true, opts.verbose,
+			newProgress("calc progress", logger), newProgress("sync progress", logger))
+	}
+	if err == nil {
+		err = endStream(rw)
 	}
-	err = diskrsync.Source(src, size, conn, conn, true, opts.verbose, calcProgress, syncProgress)
 	cerr := conn.Close()
 	if err == nil {
 		err = cerr
@@ -237,24 +411,138 @@ func startServer(targetFile string, port int, opts *options, logger logr.Logger)
 	if err != nil {
 		return err
 	}
-	conn, err := listener.Accept()
+	defer listener.Close()
+
+	return serve(w, size, listener, useReadBuffer, opts, logger)
+}
+
+// serve receives the transfer from the connections accepted by the listener.
+// The progress of a resumable transfer is kept in the state file, so that it
+// continues where it left off when the source reconnects, also if the target
+// has been restarted in the meantime.
+func serve(w spgz.SparseFile, size int64, listener net.Listener, useReadBuffer bool, opts *options,
+	logger logr.Logger) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		resumable, err := receive(w, size, conn, listener.Accept, useReadBuffer, opts, logger)
+		cerr := conn.Close()
+		if err == nil {
+			return cerr
+		}
+		if !resumable || opts.stateFile == "" {
+			return err
+		}
+		// The progress has been recorded, wait for the source to reconnect
+		logger.Error(err, "Transfer interrupted, waiting for the source to reconnect")
+	}
+}
+
+// receive handles a connection from the source, returning whether the source
+// requested a resumable transfer. The connections of the other parts of a
+// parallel transfer, and of the checksum exchange, are returned by accept.
+//
+//nolint:funlen
+func receive(w spgz.SparseFile, size int64, conn io.ReadWriter, accept func() (net.Conn, error),
+	useReadBuffer bool, opts *options, logger logr.Logger) (bool, error) {
+	stats := newTransferStats()
+	counted := &countingSparseFile{SparseFile: w, stats: stats}
+	conn = &countingReadWriter{ReadWriter: conn, n: &stats.sent}
+	countingAccept := accept
+	if accept != nil {
+		countingAccept = func() (net.Conn, error) {
+			conn, err := accept()
+			if err != nil {
+				return nil, err
+			}
+			return &countingConn{Conn: conn, n: &stats.sent}, nil
+		}
+	}
+
+	conn, magic, err := readMagic(conn, logger)
 	if err != nil {
-		return err
+		return false, err
+	}
+	var identity string
+	if string(magic) == identityMagic {
+		if identity, err = readIdentity(conn); err != nil {
+			return false, err
+		}
+		if conn, magic, err = readMagic(conn, logger); err != nil {
+			return false, err
+		}
+	}
+	checksum := checksumNone
+	if string(magic) == checksumMagic {
+		if checksum, err = readChecksumMode(conn); err != nil {
+			return false, err
+		}
+		if conn, magic, err = readMagic(conn, logger); err != nil {
+			return false, err
+		}
 	}
 
-	calcProgress := &progress{
-		progressType: "calc progress",
-		logger:       logger,
+	resumable := false
+	switch string(magic) {
+	case resumeMagic:
+		resumable = true
+		err = resumeTarget(counted, size, conn, useReadBuffer, opts.stateFile, opts, logger)
+	case deltaMagic:
+		err = deltaTarget(counted, size, conn, useReadBuffer, opts, logger)
+	case partsMagic:
+		err = receiveParts(counted, size, conn, countingAccept, useReadBuffer, opts, logger)
+	default:
+		// Not resumable, give the header back to diskrsync
+		cmdReader := io.MultiReader(bytes.NewReader(magic), conn)
+		err = diskrsync.Target(counted, size, cmdReader, conn, useReadBuffer, opts.verbose,
+			newProgress("calc progress", logger), newProgress("sync progress", logger))
 	}
-	syncProgress := &progress{
-		progressType: "sync progress",
-		logger:       logger,
+	if err != nil {
+		return resumable, err
+	}
+
+	var sourceSum, targetSum string
+	if checksumEnabled(checksum) {
+		// The transfer has completed, failures are not resumed
+		if sourceSum, targetSum, err = verifyTarget(w, countingAccept, stats, logger); err != nil {
+			return false, err
+		}
 	}
-	err = diskrsync.Target(w, size, conn, conn, useReadBuffer, opts.verbose, calcProgress, syncProgress)
+	summary := stats.summary()
+	blocksChanged := stats.blocksChanged()
+	summary.BlocksChanged = &blocksChanged
+	summary.SourceSHA256 = sourceSum
+	summary.TargetSHA256 = targetSum
+	if err := summary.finish(checksum, logger); err != nil {
+		return false, err
+	}
+	return false, writeIdentityFile(opts.pskIdentityFile, identity)
+}
+
+// changedBlocks queries the SnapshotMetadata service for the ranges that changed
+// since the base snapshot
+func changedBlocks(size int64, opts *snapshotMetadataOptions, logger logr.Logger) ([]blockRange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), snapshotMetadataTimeout)
+	defer cancel()
+	ranges, capacity, err := getChangedBlocks(ctx, opts)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if capacity != 0 && capacity != size {
+		return nil, fmt.Errorf("volume capacity %d does not match the device size %d", capacity, size)
+	}
+	logger.Info("Found changed blocks", "ranges", len(ranges), "bytes", rangesLength(ranges),
+		"base snapshot", opts.baseSnapshotID, "target snapshot", opts.targetSnapshot)
+	return ranges, nil
+}
+
+func newProgress(progressType string, logger logr.Logger) *progress {
+	return &progress{
+		progressType: progressType,
+		logger:       logger,
 	}
-	return nil
 }
 
 type progress struct {
@@ -0,0 +1,408 @@
+/*
+Copyright © 2026 The VolSync authors
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/dop251/diskrsync"
+	"github.com/dop251/spgz"
+	"github.com/go-logr/logr"
+)
+
+// A parallel transfer splits the device into contiguous parts that are each
+// synchronized with diskrsync over their own connection.
+//
+// Protocol, on each connection:
+//
+//	source -> target: partsMagic, session (uint64), size (uint64),
+//	                  part size (uint64), parts (uint64), index (uint64)
+//	target -> source: partsAccept or partsFull
+//	  partsFull: sizes differ, a single diskrsync of the whole device follows
+//	  partsAccept: diskrsync of the part follows
+//
+// The header is not compressed, compression starts after the reply.
+//
+// The source opens the connection of the first part and waits for the reply
+// before opening the others. The target only answers partsFull on the first
+// connection of a session.
+const (
+	partsMagic = "VSPARTS1"
+
+	partsAccept = byte(0)
+	partsFull   = byte(1)
+)
+
+// partsHeader describes the part of a parallel transfer sent on a connection
+type partsHeader struct {
+	session  int64
+	size     int64
+	partSize int64
+	parts    int64
+	index    int64
+}
+
+func (h *partsHeader) write(w io.Writer) error {
+	buf := bytes.NewBufferString(partsMagic)
+	for _, v := range []int64{h.session, h.size, h.partSize, h.parts, h.index} {
+		_ = writeUint64(buf, v)
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// readPartsHeader reads the header of a part, after the partsMagic
+func readPartsHeader(r io.Reader) (*partsHeader, error) {
+	h := &partsHeader{}
+	for _, v := range []*int64{&h.session, &h.size, &h.partSize, &h.parts, &h.index} {
+		var err error
+		if *v, err = readUint64(r); err != nil {
+			return nil, err
+		}
+	}
+	if h.partSize <= 0 || h.partSize%diskrsync.DefTargetBlockSize != 0 || h.parts <= 0 ||
+		h.partSize*h.parts < h.size || h.index < 0 || h.index >= h.parts {
+		return nil, fmt.Errorf("invalid part %d of %d with size %d", h.index, h.parts, h.partSize)
+	}
+	return h, nil
+}
+
+// partLength returns the length of the part starting at offset
+func (h *partsHeader) partLength(offset int64) int64 {
+	return min(h.partSize, h.size-offset)
+}
+
+// splitParts returns the size and number of the parts of a device, so that
+// each part is a multiple of the diskrsync block size
+func splitParts(size int64, streams int) (int64, int64) {
+	partSize := (size + int64(streams) - 1) / int64(streams)
+	partSize = (partSize + diskrsync.DefTargetBlockSize - 1) / diskrsync.DefTargetBlockSize *
+		diskrsync.DefTargetBlockSize
+	partSize = max(partSize, diskrsync.DefTargetBlockSize)
+	return partSize, max((size+partSize-1)/partSize, 1)
+}
+
+// parallelSource transfers the parts of the source over separate connections.
+// open returns a new handle on the source for each part, and dial a new
+// connection to the target.
+//
+//nolint:funlen
+func parallelSource(open func() (sourceReader, error), size int64, dial func() (net.Conn, error),
+	opts *options, logger logr.Logger) error {
+	var session int64
+	if err := binary.Read(rand.Reader, binary.LittleEndian, &session); err != nil {
+		return err
+	}
+	partSize, parts := splitParts(size, opts.streams)
+	logger.Info("Transferring in parallel", "parts", parts, "part size", partSize)
+
+	// The first part tells whether the target accepts a parallel transfer
+	first, err := dial()
+	if err != nil {
+		return err
+	}
+	defer first.Close()
+	if err := sendIdentity(first, opts.pskIdentity); err != nil {
+		return err
+	}
+	if err := requestChecksum(first, opts.checksum); err != nil {
+		return err
+	}
+	header := &partsHeader{session: session, size: size, partSize: partSize, parts: parts}
+	full, err := startPart(first, header, opts)
+	if err != nil {
+		return err
+	}
+	if full {
+		logger.Info("Target size differs, transferring the whole device")
+		src, err := open()
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		conn, err := compressConn(first, opts)
+		if err != nil {
+			return err
+		}
+		if err := diskrsync.Source(io.NewSectionReader(src, 0, size), size, conn, conn, true, opts.verbose,
+			newProgress("calc progress", logger), newProgress("sync progress", logger)); err != nil {
+			return err
+		}
+		return endStream(conn)
+	}
+
+	errs := make([]error, parts)
+	wg := sync.WaitGroup{}
+	for index := int64(0); index < parts; index++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[index] = sourcePart(open, dial, first, &partsHeader{session: session, size: size,
+				partSize: partSize, parts: parts, index: index}, opts, logger)
+			if errs[index] != nil {
+				// Stop the other parts
+				first.Close()
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// startPart sends the header of a part and returns whether the target requires
+// a full transfer instead
+func startPart(conn net.Conn, header *partsHeader, opts *options) (bool, error) {
+	if err := header.write(conn); err != nil {
+		return false, err
+	}
+	reply := make([]byte, 1)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return false, fmt.Errorf("could not read the reply to part %d: %w", header.index, err)
+	}
+	switch reply[0] {
+	case partsAccept:
+		return false, nil
+	case partsFull:
+		if header.index != 0 {
+			return false, fmt.Errorf("unexpected full transfer for part %d", header.index)
+		}
+		return true, nil
+	default:
+		return false, fmt.Errorf("unexpected reply to part %d: %d", header.index, reply[0])
+	}
+}
+
+// sourcePart transfers one part, on the connection of the first part or on a
+// new connection for the others
+func sourcePart(open func() (sourceReader, error), dial func() (net.Conn, error), first net.Conn,
+	header *partsHeader, opts *options, logger logr.Logger) error {
+	conn := first
+	if header.index != 0 {
+		var err error
+		if conn, err = dial(); err != nil {
+			return err
+		}
+		defer conn.Close()
+		if _, err := startPart(conn, header, opts); err != nil {
+			return err
+		}
+	}
+	src, err := open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	rw, err := compressConn(conn, opts)
+	if err != nil {
+		return err
+	}
+	offset := header.index * header.partSize
+	length := header.partLength(offset)
+	logger.Info("Transferring part", "index", header.index, "offset", offset, "size", length)
+	if err := diskrsync.Source(io.NewSectionReader(src, offset, length), length, rw, rw, true, opts.verbose,
+		newProgress(fmt.Sprintf("calc progress part %d", header.index), logger),
+		newProgress(fmt.Sprintf("sync progress part %d", header.index), logger)); err != nil {
+		return err
+	}
+	return endStream(rw)
+}
+
+// receiveParts runs the target side of a parallel transfer, after the
+// partsMagic has been read from the connection of the first part. The
+// connections of the other parts are returned by accept.
+//
+//nolint:funlen
+func receiveParts(w spgz.SparseFile, size int64, conn io.ReadWriter, accept func() (net.Conn, error),
+	useReadBuffer bool, opts *options, logger logr.Logger) error {
+	header, err := readPartsHeader(conn)
+	if err != nil {
+		return err
+	}
+	if header.size != size {
+		logger.Info("Source size differs, transferring the whole device", "source size", header.size)
+		if _, err := conn.Write([]byte{partsFull}); err != nil {
+			return err
+		}
+		rw, magic, err := readMagic(conn, logger)
+		if err != nil {
+			return err
+		}
+		return diskrsync.Target(w, size, io.MultiReader(bytes.NewReader(magic), rw), rw, useReadBuffer,
+			opts.verbose, newProgress("calc progress", logger), newProgress("sync progress", logger))
+	}
+	if accept == nil && header.parts > 1 {
+		return errors.New("parallel transfers are not supported")
+	}
+	logger.Info("Receiving in parallel", "parts", header.parts, "part size", header.partSize)
+
+	// spgz files can't be used by several goroutines at once
+	f := w
+	if c, ok := w.(*countingSparseFile); ok {
+		f = c.SparseFile
+	}
+	if _, ok := f.(*diskrsync.FixingSpgzFileWrapper); ok {
+		w = &lockedSparseFile{f: w}
+	}
+
+	// Each part and the loop accepting the connections report an error or
+	// nil once done
+	errCh := make(chan error, header.parts+1)
+	receive := func(h *partsHeader, conn io.ReadWriter) {
+		if _, err := conn.Write([]byte{partsAccept}); err != nil {
+			errCh <- err
+			return
+		}
+		errCh <- receivePart(w, h, conn, useReadBuffer, opts, logger)
+	}
+	go receive(header, conn)
+	go func() {
+		received := map[int64]bool{header.index: true}
+		for n := int64(1); n < header.parts; n++ {
+			partConn, err := accept()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			h, err := readNextPartsHeader(partConn, header, received)
+			if err != nil {
+				partConn.Close()
+				errCh <- err
+				return
+			}
+			go func() {
+				defer partConn.Close()
+				receive(h, partConn)
+			}()
+		}
+	}()
+
+	for n := int64(0); n < header.parts; n++ {
+		if err := <-errCh; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readNextPartsHeader reads the header of a part that follows the first one,
+// which must belong to the same transfer
+func readNextPartsHeader(conn io.Reader, first *partsHeader, received map[int64]bool) (*partsHeader, error) {
+	magic := make([]byte, len(partsMagic))
+	if _, err := io.ReadFull(conn, magic); err != nil {
+		return nil, err
+	}
+	if string(magic) != partsMagic {
+		return nil, errors.New("expected a part of the parallel transfer")
+	}
+	h, err := readPartsHeader(conn)
+	if err != nil {
+		return nil, err
+	}
+	if h.session != first.session || h.size != first.size || h.partSize != first.partSize ||
+		h.parts != first.parts || received[h.index] {
+		return nil, fmt.Errorf("part %d does not belong to the transfer", h.index)
+	}
+	received[h.index] = true
+	return h, nil
+}
+
+// receivePart writes one part of a parallel transfer
+func receivePart(w spgz.SparseFile, h *partsHeader, conn io.ReadWriter, useReadBuffer bool, opts *options,
+	logger logr.Logger) error {
+	rw, magic, err := readMagic(conn, logger)
+	if err != nil {
+		return err
+	}
+	offset := h.index * h.partSize
+	length := h.partLength(offset)
+	logger.Info("Receiving part", "index", h.index, "offset", offset, "size", length)
+	section := &sparseSection{f: w, offset: offset, size: length}
+	return diskrsync.Target(section, length, io.MultiReader(bytes.NewReader(magic), rw), rw, useReadBuffer,
+		opts.verbose, newProgress(fmt.Sprintf("calc progress part %d", h.index), logger),
+		newProgress(fmt.Sprintf("sync progress part %d", h.index), logger))
+}
+
+// lockedSparseFile serializes the access to a target that can't be used
+// concurrently
+type lockedSparseFile struct {
+	mu sync.Mutex
+	f  spgz.SparseFile
+}
+
+var _ spgz.SparseFile = &lockedSparseFile{}
+
+func (l *lockedSparseFile) Read(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.Read(p)
+}
+
+func (l *lockedSparseFile) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.Write(p)
+}
+
+func (l *lockedSparseFile) Seek(offset int64, whence int) (int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.Seek(offset, whence)
+}
+
+func (l *lockedSparseFile) ReadAt(p []byte, off int64) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.ReadAt(p, off)
+}
+
+func (l *lockedSparseFile) WriteAt(p []byte, off int64) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.WriteAt(p, off)
+}
+
+func (l *lockedSparseFile) PunchHole(offset, size int64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.PunchHole(offset, size)
+}
+
+func (l *lockedSparseFile) Truncate(size int64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.Truncate(size)
+}
+
+func (l *lockedSparseFile) Sync() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.Sync()
+}
+
+func (l *lockedSparseFile) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.Close()
+}
@@ -0,0 +1,167 @@
+/*
+Copyright © 2026 The VolSync authors
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dop251/diskrsync"
+	"github.com/dop251/spgz"
+	"github.com/go-logr/logr"
+)
+
+// parallelTransfer transfers src to a target of the given size, the way
+// startServer accepts the connections of the parts
+func parallelTransfer(t *testing.T, srcName string, dst spgz.SparseFile, dstSize int64,
+	opts *options) (srcErr, dstErr error) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn, err := listener.Accept()
+		if err != nil {
+			dstErr = err
+			return
+		}
+		defer conn.Close()
+		_, dstErr = receive(dst, dstSize, conn, listener.Accept, false, &options{}, logr.Discard())
+	}()
+
+	open := func() (sourceReader, error) { return openSource(srcName, logr.Discard()) }
+	dial := func() (net.Conn, error) { return net.Dial("tcp", listener.Addr().String()) }
+	srcErr = parallelSource(open, testSize, dial, opts, logr.Discard())
+	<-done
+	return srcErr, dstErr
+}
+
+func TestSplitParts(t *testing.T) {
+	partSize, parts := splitParts(10*diskrsync.DefTargetBlockSize+1, 4)
+	if partSize != 3*diskrsync.DefTargetBlockSize || parts != 4 {
+		t.Fatalf("unexpected parts %d of %d", parts, partSize)
+	}
+	// Small devices use fewer parts
+	partSize, parts = splitParts(1000, 4)
+	if partSize != diskrsync.DefTargetBlockSize || parts != 1 {
+		t.Fatalf("unexpected parts %d of %d", parts, partSize)
+	}
+}
+
+func TestParallelTransfer(t *testing.T) {
+	for _, compression := range []string{compressionNone, compressionZstd} {
+		t.Run(compression, func(t *testing.T) {
+			dir := t.TempDir()
+			data := make([]byte, testSize)
+			if _, err := rand.Read(data); err != nil {
+				t.Fatal(err)
+			}
+			// Leave some blocks unchanged
+			base := bytes.Clone(data)
+			if _, err := rand.Read(base[testSegmentSize : 2*testSegmentSize]); err != nil {
+				t.Fatal(err)
+			}
+			writeTestFile(t, filepath.Join(dir, "src"), data)
+			dst := writeTestFile(t, filepath.Join(dir, "dst"), base)
+
+			opts := &options{streams: 3, compression: compression, compressionLevel: defaultCompressionLevel}
+			srcErr, dstErr := parallelTransfer(t, filepath.Join(dir, "src"), spgz.NewSparseFileWithFallback(dst),
+				testSize, opts)
+			if srcErr != nil || dstErr != nil {
+				t.Fatalf("transfer failed: %v, %v", srcErr, dstErr)
+			}
+			result, err := os.ReadFile(dst.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(result, data) {
+				t.Fatal("target does not match the source")
+			}
+		})
+	}
+}
+
+func TestParallelTransferToSpgz(t *testing.T) {
+	dir := t.TempDir()
+	data := make([]byte, testSize)
+	if _, err := rand.Read(data[:testSize/2]); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(dir, "src"), data)
+
+	f, err := os.OpenFile(filepath.Join(dir, "dst"), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sf, err := spgz.NewFromFileSize(f, os.O_RDWR|os.O_CREATE, diskrsync.DefTargetBlockSize)
+	if err != nil {
+		t.Skipf("spgz is not supported: %v", err)
+	}
+	dst := &diskrsync.FixingSpgzFileWrapper{SpgzFile: sf}
+	if err := dst.Truncate(testSize); err != nil {
+		t.Fatal(err)
+	}
+
+	srcErr, dstErr := parallelTransfer(t, filepath.Join(dir, "src"), dst, testSize,
+		&options{streams: 4, compression: compressionZstd, compressionLevel: 1})
+	if srcErr != nil || dstErr != nil {
+		t.Fatalf("transfer failed: %v, %v", srcErr, dstErr)
+	}
+	result := make([]byte, testSize)
+	if _, err := dst.ReadAt(result, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := dst.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result, data) {
+		t.Fatal("target does not match the source")
+	}
+}
+
+func TestParallelTransferSizeMismatch(t *testing.T) {
+	dir := t.TempDir()
+	data := make([]byte, testSize)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(dir, "src"), data)
+	dst := writeTestFile(t, filepath.Join(dir, "dst"), make([]byte, testSize-4096))
+
+	// The target falls back to a full transfer on the first connection
+	srcErr, dstErr := parallelTransfer(t, filepath.Join(dir, "src"), spgz.NewSparseFileWithFallback(dst),
+		testSize-4096, &options{streams: 3, compression: compressionZstd, compressionLevel: 3})
+	if srcErr != nil || dstErr != nil {
+		t.Fatalf("transfer failed: %v, %v", srcErr, dstErr)
+	}
+	result, err := os.ReadFile(dst.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result, data) {
+		t.Fatal("target does not match the source")
+	}
+}
@@ -0,0 +1,443 @@
+/*
+Copyright © 2026 The VolSync authors
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/dop251/diskrsync"
+	"github.com/dop251/spgz"
+	"github.com/go-logr/logr"
+)
+
+// A resumable transfer splits the device into segments that are each
+// synchronized with diskrsync. After each segment, the target records the
+// offset reached and a running hash of the data up to that offset in its state
+// file. When the source reconnects, it verifies the running hash against its
+// own data and continues from the recorded offset.
+//
+// Protocol, after the connection is established:
+//
+//	source -> target: resumeMagic, size (uint64), segment size (uint64)
+//	target -> source: resumeSegmented or resumeFull
+//	  resumeFull: sizes differ, a single diskrsync of the whole device follows
+//	  resumeSegmented:
+//	    target -> source: offset (uint64), running hash
+//	    source -> target: start offset (uint64), either offset or 0
+//	    for each segment: diskrsync of the segment, then
+//	      source -> target: segment hash
+//	      target -> source: resumeAck, once the checkpoint is written
+//
+// Sources that do not resume start with the diskrsync header, which the target
+// recognizes and handles as before.
+const (
+	resumeMagic = "VSRESUM1"
+
+	resumeSegmented = byte(0)
+	resumeFull      = byte(1)
+	resumeAck       = byte(2)
+
+	defaultSegmentSize = 1024 * 1024 * 1024
+)
+
+// resumeState is the checkpoint kept by the target between connections
+type resumeState struct {
+	Size        int64  `json:"size"`
+	SegmentSize int64  `json:"segmentSize"`
+	Offset      int64  `json:"offset"`
+	Hash        string `json:"hash"`
+}
+
+// loadResumeState reads the checkpoint of an interrupted transfer. Without a
+// state file, transfers always start from the beginning.
+func loadResumeState(stateFile string) (*resumeState, error) {
+	if stateFile == "" {
+		return &resumeState{}, nil
+	}
+	data, err := os.ReadFile(stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return &resumeState{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	state := &resumeState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("invalid state file %s: %w", stateFile, err)
+	}
+	return state, nil
+}
+
+// save writes the state file atomically so that an interrupted write leaves
+// the previous checkpoint in place
+func (s *resumeState) save(stateFile string) error {
+	if stateFile == "" {
+		return nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(stateFile), 0755); err != nil {
+		return err
+	}
+	tmpFile := stateFile + ".tmp"
+	f, err := os.Create(tmpFile)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, stateFile)
+}
+
+func (s *resumeState) hash() ([]byte, error) {
+	if s.Hash == "" {
+		return make([]byte, sha256.Size), nil
+	}
+	return hex.DecodeString(s.Hash)
+}
+
+// chainHash adds the hash of a segment to the running hash
+func chainHash(running, segment []byte) []byte {
+	h := sha256.New()
+	h.Write(running)
+	h.Write(segment)
+	return h.Sum(nil)
+}
+
+// hashSegment returns the hash of the data of a segment
+func hashSegment(src io.ReaderAt, offset, size int64) ([]byte, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, io.NewSectionReader(src, offset, size)); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+func segmentLength(offset, segmentSize, size int64) int64 {
+	return min(segmentSize, size-offset)
+}
+
+func writeUint64(w io.Writer, v int64) error {
+	return binary.Write(w, binary.LittleEndian, uint64(v))
+}
+
+func readUint64(r io.Reader) (int64, error) {
+	var v uint64
+	err := binary.Read(r, binary.LittleEndian, &v)
+	return int64(v), err
+}
+
+// resumeSource runs the source side of a resumable transfer
+//
+//nolint:funlen
+func resumeSource(src, hashSrc io.ReaderAt, size, segmentSize int64, conn io.ReadWriter,
+	opts *options, logger logr.Logger) error {
+	hello := bytes.NewBufferString(resumeMagic)
+	_ = writeUint64(hello, size)
+	_ = writeUint64(hello, segmentSize)
+	if _, err := conn.Write(hello.Bytes()); err != nil {
+		return err
+	}
+
+	mode := make([]byte, 1)
+	if _, err := io.ReadFull(conn, mode); err != nil {
+		return fmt.Errorf("could not read resume mode: %w", err)
+	}
+	if mode[0] == resumeFull {
+		logger.Info("Target size differs, transferring the whole device")
+		return diskrsync.Source(io.NewSectionReader(src, 0, size), size, conn, conn, true, opts.verbose,
+			newProgress("calc progress", logger), newProgress("sync progress", logger))
+	}
+
+	offset, err := readUint64(conn)
+	if err != nil {
+		return err
+	}
+	targetHash := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(conn, targetHash); err != nil {
+		return err
+	}
+
+	// Verify that the data already transferred matches the source before
+	// skipping it
+	if offset > 0 {
+		logger.Info("Verifying data already transferred", "offset", offset)
+		running := make([]byte, sha256.Size)
+		for pos := int64(0); pos < offset; pos += segmentSize {
+			segmentHash, err := hashSegment(hashSrc, pos, segmentLength(pos, segmentSize, size))
+			if err != nil {
+				return err
+			}
+			running = chainHash(running, segmentHash)
+		}
+		if !bytes.Equal(running, targetHash) {
+			logger.Info("Source data has changed since the interrupted transfer, starting over")
+			offset = 0
+		}
+	}
+	if err := writeUint64(conn, offset); err != nil {
+		return err
+	}
+	if offset > 0 {
+		logger.Info("Resumed transfer", "skipped bytes", offset, "total bytes", size)
+	}
+
+	ack := make([]byte, 1)
+	for pos := offset; pos < size; pos += segmentSize {
+		length := segmentLength(pos, segmentSize, size)
+		logger.Info("Transferring segment", "offset", pos, "size", length)
+
+		hashCh := make(chan []byte, 1)
+		errCh := make(chan error, 1)
+		go func() {
+			segmentHash, err := hashSegment(hashSrc, pos, length)
+			hashCh <- segmentHash
+			errCh <- err
+		}()
+
+		err := diskrsync.Source(io.NewSectionReader(src, pos, length), length, conn, conn, true, opts.verbose,
+			newProgress("calc progress", logger), newProgress("sync progress", logger))
+		segmentHash, hashErr := <-hashCh, <-errCh
+		if err != nil {
+			return err
+		}
+		if hashErr != nil {
+			return hashErr
+		}
+
+		if _, err := conn.Write(segmentHash); err != nil {
+			return err
+		}
+		if _, err := io.ReadFull(conn, ack); err != nil {
+			return fmt.Errorf("could not read checkpoint acknowledgement: %w", err)
+		}
+		if ack[0] != resumeAck {
+			return fmt.Errorf("unexpected checkpoint acknowledgement: %d", ack[0])
+		}
+	}
+	return nil
+}
+
+// resumeTarget runs the target side of a resumable transfer, after the
+// resumeMagic has been read from the connection. The state file is removed once
+// the whole device has been transferred.
+//
+//nolint:funlen
+func resumeTarget(w spgz.SparseFile, size int64, conn io.ReadWriter, useReadBuffer bool, stateFile string,
+	opts *options, logger logr.Logger) error {
+	sourceSize, err := readUint64(conn)
+	if err != nil {
+		return err
+	}
+	segmentSize, err := readUint64(conn)
+	if err != nil {
+		return err
+	}
+	if segmentSize <= 0 || segmentSize%diskrsync.DefTargetBlockSize != 0 {
+		return fmt.Errorf("invalid segment size %d", segmentSize)
+	}
+
+	if sourceSize != size {
+		logger.Info("Source size differs, transferring the whole device", "source size", sourceSize)
+		if _, err := conn.Write([]byte{resumeFull}); err != nil {
+			return err
+		}
+		return diskrsync.Target(w, size, conn, conn, useReadBuffer, opts.verbose,
+			newProgress("calc progress", logger), newProgress("sync progress", logger))
+	}
+
+	state, err := loadResumeState(stateFile)
+	if err != nil {
+		return err
+	}
+	if state.Size != size || state.SegmentSize != segmentSize {
+		state = &resumeState{Size: size, SegmentSize: segmentSize}
+	}
+	running, err := state.hash()
+	if err != nil {
+		return err
+	}
+
+	reply := bytes.NewBuffer([]byte{resumeSegmented})
+	_ = writeUint64(reply, state.Offset)
+	reply.Write(running)
+	if _, err := conn.Write(reply.Bytes()); err != nil {
+		return err
+	}
+
+	offset, err := readUint64(conn)
+	if err != nil {
+		return err
+	}
+	if offset != state.Offset {
+		logger.Info("Source data has changed since the interrupted transfer, starting over")
+		state.Offset = 0
+		state.Hash = ""
+		running = make([]byte, sha256.Size)
+	} else if offset > 0 {
+		logger.Info("Resumed transfer", "skipped bytes", offset, "total bytes", size)
+	}
+
+	segmentHash := make([]byte, sha256.Size)
+	for pos := offset; pos < size; pos += segmentSize {
+		length := segmentLength(pos, segmentSize, size)
+		logger.Info("Receiving segment", "offset", pos, "size", length)
+
+		segment := &sparseSection{f: w, offset: pos, size: length}
+		err := diskrsync.Target(segment, length, conn, conn, useReadBuffer, opts.verbose,
+			newProgress("calc progress", logger), newProgress("sync progress", logger))
+		if err != nil {
+			return err
+		}
+		if _, err := io.ReadFull(conn, segmentHash); err != nil {
+			return fmt.Errorf("could not read segment hash: %w", err)
+		}
+
+		// Make sure the segment is on disk before recording the checkpoint
+		if err := w.Sync(); err != nil {
+			return err
+		}
+		running = chainHash(running, segmentHash)
+		state.Offset = pos + length
+		state.Hash = hex.EncodeToString(running)
+		if err := state.save(stateFile); err != nil {
+			return err
+		}
+		if _, err := conn.Write([]byte{resumeAck}); err != nil {
+			return err
+		}
+	}
+
+	if stateFile == "" {
+		return nil
+	}
+	if err := os.Remove(stateFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// sparseSection gives diskrsync access to one segment of the target. Only the
+// positioned reads and writes of the target are used, so that the sections of a
+// parallel transfer can share it.
+type sparseSection struct {
+	f      spgz.SparseFile
+	offset int64
+	size   int64
+	pos    int64
+}
+
+var _ spgz.SparseFile = &sparseSection{}
+
+func (s *sparseSection) Read(p []byte) (int, error) {
+	if s.pos >= s.size {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > s.size-s.pos {
+		p = p[:s.size-s.pos]
+	}
+	n, err := s.f.ReadAt(p, s.offset+s.pos)
+	s.pos += int64(n)
+	if n > 0 && errors.Is(err, io.EOF) {
+		err = nil
+	}
+	return n, err
+}
+
+func (s *sparseSection) Write(p []byte) (int, error) {
+	if s.pos+int64(len(p)) > s.size {
+		return 0, errors.New("write beyond the end of the segment")
+	}
+	n, err := s.f.WriteAt(p, s.offset+s.pos)
+	s.pos += int64(n)
+	return n, err
+}
+
+func (s *sparseSection) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += s.pos
+	case io.SeekEnd:
+		offset += s.size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	s.pos = offset
+	return offset, nil
+}
+
+func (s *sparseSection) ReadAt(p []byte, off int64) (int, error) {
+	if off >= s.size {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > s.size-off {
+		n, err := s.f.ReadAt(p[:s.size-off], s.offset+off)
+		if err == nil {
+			err = io.EOF
+		}
+		return n, err
+	}
+	return s.f.ReadAt(p, s.offset+off)
+}
+
+func (s *sparseSection) WriteAt(p []byte, off int64) (int, error) {
+	if off+int64(len(p)) > s.size {
+		return 0, errors.New("write beyond the end of the segment")
+	}
+	return s.f.WriteAt(p, s.offset+off)
+}
+
+func (s *sparseSection) PunchHole(offset, size int64) error {
+	return s.f.PunchHole(s.offset+offset, size)
+}
+
+func (s *sparseSection) Truncate(int64) error {
+	return errors.New("a segment can not be truncated")
+}
+
+func (s *sparseSection) Sync() error {
+	return s.f.Sync()
+}
+
+// Close leaves the target open for the following segments
+func (s *sparseSection) Close() error {
+	return nil
+}
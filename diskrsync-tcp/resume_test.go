@@ -0,0 +1,311 @@
+/*
+Copyright © 2026 The VolSync authors
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dop251/diskrsync"
+	"github.com/dop251/spgz"
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+)
+
+const (
+	testSegmentSize = 4 * diskrsync.DefTargetBlockSize
+	testSize        = 5*testSegmentSize + 1000
+)
+
+// limitedConn fails once the given number of bytes has been written
+type limitedConn struct {
+	net.Conn
+	remaining int
+}
+
+func (c *limitedConn) Write(p []byte) (int, error) {
+	if len(p) <= c.remaining {
+		n, err := c.Conn.Write(p)
+		c.remaining -= n
+		return n, err
+	}
+	n, _ := c.Conn.Write(p[:c.remaining])
+	c.remaining = 0
+	c.Conn.Close()
+	return n, errors.New("connection interrupted")
+}
+
+// connPair returns both ends of a TCP connection, which unlike net.Pipe buffers
+// the data like the connection between the movers
+func connPair(t *testing.T) (net.Conn, net.Conn) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	srcConn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dstConn, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srcConn, dstConn
+}
+
+func writeTestFile(t *testing.T, name string, data []byte) *os.File {
+	t.Helper()
+	if err := os.WriteFile(name, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(name, os.O_RDWR, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+// transfer runs a resumable transfer between the source and target files,
+// interrupting it after the source has written limit bytes if limit > 0
+func transfer(t *testing.T, src *os.File, dst *os.File, stateFile string, limit int) (srcErr, dstErr error) {
+	t.Helper()
+	opts := &options{verbose: false}
+	srcConn, dstConn := connPair(t)
+	var conn net.Conn = srcConn
+	if limit > 0 {
+		conn = &limitedConn{Conn: srcConn, remaining: limit}
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, dstErr = receive(spgz.NewSparseFileWithFallback(dst), testSize, dstConn, nil, false,
+			&options{stateFile: stateFile}, logr.Discard())
+		dstConn.Close()
+	}()
+	srcErr = resumeSource(src, src, testSize, testSegmentSize, conn, opts, logr.Discard())
+	srcConn.Close()
+	wg.Wait()
+	return srcErr, dstErr
+}
+
+func TestResumeInterruptedTransfer(t *testing.T) {
+	dir := t.TempDir()
+	data := make([]byte, testSize)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	src := writeTestFile(t, filepath.Join(dir, "src"), data)
+	dst := writeTestFile(t, filepath.Join(dir, "dst"), make([]byte, testSize))
+	stateFile := filepath.Join(dir, "state.json")
+
+	// Interrupt the transfer during the third segment
+	srcErr, dstErr := transfer(t, src, dst, stateFile, 2*testSegmentSize+testSegmentSize/2)
+	if srcErr == nil || dstErr == nil {
+		t.Fatalf("expected the transfer to be interrupted, got %v, %v", srcErr, dstErr)
+	}
+	state, err := loadResumeState(stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Offset != 2*testSegmentSize {
+		t.Fatalf("expected a checkpoint after 2 segments, got offset %d", state.Offset)
+	}
+
+	srcErr, dstErr = transfer(t, src, dst, stateFile, 0)
+	if srcErr != nil || dstErr != nil {
+		t.Fatalf("resumed transfer failed: %v, %v", srcErr, dstErr)
+	}
+	result, err := os.ReadFile(dst.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result, data) {
+		t.Fatal("target does not match the source")
+	}
+	if _, err := os.Stat(stateFile); !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("state file was not removed after the transfer completed")
+	}
+}
+
+func TestResumeChangedSource(t *testing.T) {
+	dir := t.TempDir()
+	data := make([]byte, testSize)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	src := writeTestFile(t, filepath.Join(dir, "src"), data)
+	dst := writeTestFile(t, filepath.Join(dir, "dst"), make([]byte, testSize))
+	stateFile := filepath.Join(dir, "state.json")
+
+	// A checkpoint whose running hash does not match the source data
+	state := &resumeState{Size: testSize, SegmentSize: testSegmentSize, Offset: 2 * testSegmentSize,
+		Hash: "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"}
+	if err := state.save(stateFile); err != nil {
+		t.Fatal(err)
+	}
+
+	srcErr, dstErr := transfer(t, src, dst, stateFile, 0)
+	if srcErr != nil || dstErr != nil {
+		t.Fatalf("transfer failed: %v, %v", srcErr, dstErr)
+	}
+	result, err := os.ReadFile(dst.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result, data) {
+		t.Fatal("target does not match the source")
+	}
+}
+
+func TestReceiveFromLegacySource(t *testing.T) {
+	dir := t.TempDir()
+	data := make([]byte, testSize)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	src := writeTestFile(t, filepath.Join(dir, "src"), data)
+	dst := writeTestFile(t, filepath.Join(dir, "dst"), make([]byte, testSize))
+
+	srcConn, dstConn := connPair(t)
+	var dstErr error
+	done := make(chan struct{})
+	go func() {
+		_, dstErr = receive(spgz.NewSparseFileWithFallback(dst), testSize, dstConn, nil, false,
+			&options{stateFile: filepath.Join(dir, "state.json")}, logr.Discard())
+		dstConn.Close()
+		close(done)
+	}()
+	srcErr := diskrsync.Source(src, testSize, srcConn, srcConn, false, false, nil, nil)
+	srcConn.Close()
+	<-done
+	if srcErr != nil || dstErr != nil {
+		t.Fatalf("transfer failed: %v, %v", srcErr, dstErr)
+	}
+	result, err := os.ReadFile(dst.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result, data) {
+		t.Fatal("target does not match the source")
+	}
+}
+
+// startTarget serves the target file on a new listener, like a target process
+// that has just started. Stopping it closes the listener and the file.
+func startTarget(t *testing.T, name, stateFile string, logger logr.Logger) (addr string, stop func() error) {
+	t.Helper()
+	f, err := os.OpenFile(name, os.O_RDWR, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		f.Close()
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- serve(spgz.NewSparseFileWithFallback(f), testSize, listener, false,
+			&options{stateFile: stateFile}, logger)
+	}()
+	return listener.Addr().String(), func() error {
+		listener.Close()
+		err := <-done
+		f.Close()
+		return err
+	}
+}
+
+func TestResumeAfterTargetRestart(t *testing.T) {
+	dir := t.TempDir()
+	data := make([]byte, testSize)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	src := writeTestFile(t, filepath.Join(dir, "src"), data)
+	dstName := filepath.Join(dir, "dst")
+	writeTestFile(t, dstName, make([]byte, testSize))
+	stateFile := filepath.Join(dir, "state.json")
+	opts := &options{verbose: false}
+
+	// Interrupt the transfer during the third segment, then stop the target
+	// while it waits for the source to reconnect
+	addr, stop := startTarget(t, dstName, stateFile, logr.Discard())
+	srcConn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := &limitedConn{Conn: srcConn, remaining: 2*testSegmentSize + testSegmentSize/2}
+	if err := resumeSource(src, src, testSize, testSegmentSize, conn, opts, logr.Discard()); err == nil {
+		t.Fatal("expected the transfer to be interrupted")
+	}
+	srcConn.Close()
+	_ = stop()
+
+	state, err := loadResumeState(stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Offset != 2*testSegmentSize {
+		t.Fatalf("expected a checkpoint after 2 segments, got offset %d", state.Offset)
+	}
+
+	// The restarted target resumes from the recorded segment
+	var resumed []string
+	logger := funcr.New(func(_, args string) {
+		if strings.Contains(args, `"msg"="Resumed transfer"`) {
+			resumed = append(resumed, args)
+		}
+	}, funcr.Options{})
+	addr, stop = startTarget(t, dstName, stateFile, logger)
+	srcConn, err = net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srcErr := resumeSource(src, src, testSize, testSegmentSize, srcConn, opts, logr.Discard())
+	srcConn.Close()
+	if err := stop(); srcErr != nil || err != nil {
+		t.Fatalf("resumed transfer failed: %v, %v", srcErr, err)
+	}
+	skipped := fmt.Sprintf(`"skipped bytes"=%d`, 2*testSegmentSize)
+	if len(resumed) != 1 || !strings.Contains(resumed[0], skipped) {
+		t.Fatalf("expected the transfer to resume at offset %d, got %v", 2*testSegmentSize, resumed)
+	}
+	result, err := os.ReadFile(dstName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result, data) {
+		t.Fatal("target does not match the source")
+	}
+	if _, err := os.Stat(stateFile); !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("state file was not removed after the transfer completed")
+	}
+}
@@ -0,0 +1,283 @@
+/*
+Copyright © 2026 The VolSync authors
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// The CSI SnapshotMetadata service lists the blocks that changed between two
+// snapshots of a volume. Only the GetMetadataDelta call is used, so the
+// messages are described here instead of vendoring the generated client of
+// github.com/kubernetes-csi/external-snapshot-metadata.
+const (
+	snapshotMetadataService = "api.SnapshotMetadata"
+	getMetadataDeltaMethod  = "/" + snapshotMetadataService + "/GetMetadataDelta"
+
+	// Maximum number of block ranges per response message
+	metadataMaxResults = 1024
+
+	// Time allowed to list the changed blocks
+	snapshotMetadataTimeout = 10 * time.Minute
+)
+
+// snapshotMetadataMessages describe the messages of the GetMetadataDelta call
+type snapshotMetadataMessages struct {
+	request  protoreflect.MessageDescriptor
+	response protoreflect.MessageDescriptor
+	block    protoreflect.MessageDescriptor
+}
+
+// newSnapshotMetadataMessages builds the descriptors of the messages. They are
+// only built when the changed blocks are requested, so that an error falls back
+// to a full transfer.
+func newSnapshotMetadataMessages() (*snapshotMetadataMessages, error) {
+	field := func(name string, number int32, kind descriptorpb.FieldDescriptorProto_Type,
+		label descriptorpb.FieldDescriptorProto_Label, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Type:     kind.Enum(),
+			Label:    label.Enum(),
+		}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	const (
+		optional = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+		repeated = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+		str      = descriptorpb.FieldDescriptorProto_TYPE_STRING
+		i64      = descriptorpb.FieldDescriptorProto_TYPE_INT64
+		i32      = descriptorpb.FieldDescriptorProto_TYPE_INT32
+		enum     = descriptorpb.FieldDescriptorProto_TYPE_ENUM
+		message  = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+	)
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("snapshot-metadata.proto"),
+		Package: proto.String("api"),
+		Syntax:  proto.String("proto3"),
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("BlockMetadataType"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("UNKNOWN"), Number: proto.Int32(0)},
+				{Name: proto.String("FIXED_LENGTH"), Number: proto.Int32(1)},
+				{Name: proto.String("VARIABLE_LENGTH"), Number: proto.Int32(2)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("BlockMetadata"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("byte_offset", 1, i64, optional, ""),
+					field("size_bytes", 2, i64, optional, ""),
+				},
+			},
+			{
+				Name: proto.String("GetMetadataDeltaRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("security_token", 1, str, optional, ""),
+					field("namespace", 2, str, optional, ""),
+					field("base_snapshot_id", 3, str, optional, ""),
+					field("target_snapshot_name", 4, str, optional, ""),
+					field("starting_offset", 5, i64, optional, ""),
+					field("max_results", 6, i32, optional, ""),
+				},
+			},
+			{
+				Name: proto.String("GetMetadataDeltaResponse"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("block_metadata_type", 1, enum, optional, ".api.BlockMetadataType"),
+					field("volume_capacity_bytes", 2, i64, optional, ""),
+					field("block_metadata", 3, message, repeated, ".api.BlockMetadata"),
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("SnapshotMetadata"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:            proto.String("GetMetadataDelta"),
+				InputType:       proto.String(".api.GetMetadataDeltaRequest"),
+				OutputType:      proto.String(".api.GetMetadataDeltaResponse"),
+				ServerStreaming: proto.Bool(true),
+			}},
+		}},
+	}
+	fd, err := protodesc.NewFile(file, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe the SnapshotMetadata service: %w", err)
+	}
+	return &snapshotMetadataMessages{
+		request:  fd.Messages().ByName("GetMetadataDeltaRequest"),
+		response: fd.Messages().ByName("GetMetadataDeltaResponse"),
+		block:    fd.Messages().ByName("BlockMetadata"),
+	}, nil
+}
+
+// snapshotMetadataOptions locate the SnapshotMetadata service and the
+// snapshots to compare
+type snapshotMetadataOptions struct {
+	address        string
+	caFile         string
+	tokenFile      string
+	namespace      string
+	baseSnapshotID string
+	targetSnapshot string
+}
+
+func (o *snapshotMetadataOptions) enabled() bool {
+	return o.address != "" && o.baseSnapshotID != "" && o.targetSnapshot != ""
+}
+
+// blockRange is a range of bytes of the device
+type blockRange struct {
+	offset int64
+	length int64
+}
+
+// getChangedBlocks returns the ranges of the device that changed between the
+// base and target snapshots, sorted and merged
+func getChangedBlocks(ctx context.Context, opts *snapshotMetadataOptions) ([]blockRange, int64, error) {
+	messages, err := newSnapshotMetadataMessages()
+	if err != nil {
+		return nil, 0, err
+	}
+	creds := insecure.NewCredentials()
+	if opts.caFile != "" {
+		caCert, err := os.ReadFile(opts.caFile)
+		if err != nil {
+			return nil, 0, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, 0, fmt.Errorf("no certificates found in %s", opts.caFile)
+		}
+		creds = credentials.NewTLS(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12})
+	}
+	token := ""
+	if opts.tokenFile != "" {
+		data, err := os.ReadFile(opts.tokenFile)
+		if err != nil {
+			return nil, 0, err
+		}
+		token = strings.TrimSpace(string(data))
+	}
+
+	conn, err := grpc.NewClient(opts.address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer conn.Close()
+
+	capacity := int64(0)
+	ranges, err := getMetadataDelta(ctx, conn, messages, opts, token, &capacity)
+	if err != nil {
+		return nil, 0, err
+	}
+	return mergeRanges(ranges), capacity, nil
+}
+
+// getMetadataDelta reads the changed blocks from the stream returned by the
+// service
+func getMetadataDelta(ctx context.Context, conn grpc.ClientConnInterface, messages *snapshotMetadataMessages,
+	opts *snapshotMetadataOptions, token string, capacity *int64) ([]blockRange, error) {
+	request := dynamicpb.NewMessage(messages.request)
+	setField(request, "security_token", protoreflect.ValueOfString(token))
+	setField(request, "namespace", protoreflect.ValueOfString(opts.namespace))
+	setField(request, "base_snapshot_id", protoreflect.ValueOfString(opts.baseSnapshotID))
+	setField(request, "target_snapshot_name", protoreflect.ValueOfString(opts.targetSnapshot))
+	setField(request, "starting_offset", protoreflect.ValueOfInt64(0))
+	setField(request, "max_results", protoreflect.ValueOfInt32(metadataMaxResults))
+
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, getMetadataDeltaMethod)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(request); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	ranges := []blockRange{}
+	for {
+		response := dynamicpb.NewMessage(messages.response)
+		err := stream.RecvMsg(response)
+		if errors.Is(err, io.EOF) {
+			return ranges, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		*capacity = getField(response, "volume_capacity_bytes").Int()
+		blocks := getField(response, "block_metadata").List()
+		for i := 0; i < blocks.Len(); i++ {
+			block := blocks.Get(i).Message()
+			ranges = append(ranges, blockRange{
+				offset: block.Get(messages.block.Fields().ByName("byte_offset")).Int(),
+				length: block.Get(messages.block.Fields().ByName("size_bytes")).Int(),
+			})
+		}
+	}
+}
+
+func setField(m *dynamicpb.Message, name protoreflect.Name, value protoreflect.Value) {
+	m.Set(m.Descriptor().Fields().ByName(name), value)
+}
+
+func getField(m *dynamicpb.Message, name protoreflect.Name) protoreflect.Value {
+	return m.Get(m.Descriptor().Fields().ByName(name))
+}
+
+// mergeRanges sorts the ranges and merges those that overlap or are adjacent
+func mergeRanges(ranges []blockRange) []blockRange {
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].offset < ranges[j].offset })
+	merged := []blockRange{}
+	for _, r := range ranges {
+		if r.length <= 0 {
+			continue
+		}
+		if n := len(merged); n > 0 && merged[n-1].offset+merged[n-1].length >= r.offset {
+			end := max(merged[n-1].offset+merged[n-1].length, r.offset+r.length)
+			merged[n-1].length = end - merged[n-1].offset
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
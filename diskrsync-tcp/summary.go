@@ -0,0 +1,322 @@
+/*
+Copyright © 2026 The VolSync authors
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/dop251/diskrsync"
+	"github.com/dop251/spgz"
+	"github.com/go-logr/logr"
+)
+
+// Both ends log a summary of the sync once the transfer has completed. When the
+// source requests it, both ends also compute the SHA-256 of the device and
+// exchange them.
+//
+// Protocol:
+//
+//	on the first connection of the transfer, before compression:
+//	  source -> target: checksumMagic, mode (byte, the index in checksumModes)
+//	once the transfer has completed, on a new connection:
+//	  source -> target: checksumMagic, size (uint64)
+//	  source -> target: SHA-256 of the source
+//	  target -> source: SHA-256 of the first size bytes of the target,
+//	                    blocks changed (uint64)
+const (
+	checksumMagic = "VSCHKSM1"
+
+	checksumNone    = "none"
+	checksumReport  = "report"
+	checksumEnforce = "enforce"
+)
+
+var checksumModes = []string{checksumNone, checksumReport, checksumEnforce}
+
+// syncSummary is logged as JSON at the end of a sync. The checksums are only
+// computed when requested by the source, and the source only knows the number
+// of blocks changed from the target when they are.
+type syncSummary struct {
+	BytesRead       int64   `json:"bytesRead"`
+	BytesSent       int64   `json:"bytesSent"`
+	BlocksChanged   *int64  `json:"blocksChanged,omitempty"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	SourceSHA256    string  `json:"sourceSHA256,omitempty"`
+	TargetSHA256    string  `json:"targetSHA256,omitempty"`
+}
+
+// finish logs the summary, and returns an error if the checksums differ and
+// the mode is checksumEnforce
+func (s *syncSummary) finish(mode string, logger logr.Logger) error {
+	logger.Info("Sync summary", "summary", s)
+	if s.SourceSHA256 == s.TargetSHA256 {
+		return nil
+	}
+	if mode == checksumEnforce {
+		return errors.New("the checksums of the source and target devices differ")
+	}
+	logger.Info("The checksums of the source and target devices differ")
+	return nil
+}
+
+// transferStats counts the data of a sync. The data read for the checksums is
+// not counted.
+type transferStats struct {
+	start time.Time
+	// Read from the local device
+	read atomic.Int64
+	// Written to the connections
+	sent atomic.Int64
+	// Written to or punched out of the target
+	written atomic.Int64
+}
+
+func newTransferStats() *transferStats {
+	return &transferStats{start: time.Now()}
+}
+
+func (s *transferStats) summary() *syncSummary {
+	return &syncSummary{
+		BytesRead:       s.read.Load(),
+		BytesSent:       s.sent.Load(),
+		DurationSeconds: time.Since(s.start).Seconds(),
+	}
+}
+
+// blocksChanged returns the number of diskrsync blocks written to the target
+func (s *transferStats) blocksChanged() int64 {
+	return (s.written.Load() + diskrsync.DefTargetBlockSize - 1) / diskrsync.DefTargetBlockSize
+}
+
+// countingSource counts the data read from the source
+type countingSource struct {
+	sourceReader
+	n *atomic.Int64
+}
+
+func (c *countingSource) Read(p []byte) (int, error) {
+	n, err := c.sourceReader.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+func (c *countingSource) ReadAt(p []byte, off int64) (int, error) {
+	n, err := c.sourceReader.ReadAt(p, off)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// countingConn counts the data written to a connection
+type countingConn struct {
+	net.Conn
+	n *atomic.Int64
+}
+
+func (c *countingConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// countingReadWriter counts the data written to the first connection of the
+// target
+type countingReadWriter struct {
+	io.ReadWriter
+	n *atomic.Int64
+}
+
+func (c *countingReadWriter) Write(p []byte) (int, error) {
+	n, err := c.ReadWriter.Write(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// countingSparseFile counts the data read from and written to the target. It
+// can be used concurrently if the target can.
+type countingSparseFile struct {
+	spgz.SparseFile
+	stats *transferStats
+}
+
+var _ spgz.SparseFile = &countingSparseFile{}
+
+func (c *countingSparseFile) Read(p []byte) (int, error) {
+	n, err := c.SparseFile.Read(p)
+	c.stats.read.Add(int64(n))
+	return n, err
+}
+
+func (c *countingSparseFile) ReadAt(p []byte, off int64) (int, error) {
+	n, err := c.SparseFile.ReadAt(p, off)
+	c.stats.read.Add(int64(n))
+	return n, err
+}
+
+func (c *countingSparseFile) Write(p []byte) (int, error) {
+	n, err := c.SparseFile.Write(p)
+	c.stats.written.Add(int64(n))
+	return n, err
+}
+
+func (c *countingSparseFile) WriteAt(p []byte, off int64) (int, error) {
+	n, err := c.SparseFile.WriteAt(p, off)
+	c.stats.written.Add(int64(n))
+	return n, err
+}
+
+func (c *countingSparseFile) PunchHole(offset, size int64) error {
+	err := c.SparseFile.PunchHole(offset, size)
+	if err == nil {
+		c.stats.written.Add(size)
+	}
+	return err
+}
+
+func checksumEnabled(mode string) bool {
+	return mode != "" && mode != checksumNone
+}
+
+// requestChecksum asks the target to exchange the checksums once the transfer
+// has completed
+func requestChecksum(w io.Writer, mode string) error {
+	if !checksumEnabled(mode) {
+		return nil
+	}
+	index := slices.Index(checksumModes, mode)
+	if index < 0 {
+		return fmt.Errorf("unsupported checksum mode %q", mode)
+	}
+	_, err := w.Write(append([]byte(checksumMagic), byte(index)))
+	return err
+}
+
+// readChecksumMode reads the mode requested by the source, after the
+// checksumMagic
+func readChecksumMode(r io.Reader) (string, error) {
+	mode := make([]byte, 1)
+	if _, err := io.ReadFull(r, mode); err != nil {
+		return "", err
+	}
+	if int(mode[0]) >= len(checksumModes) {
+		return "", fmt.Errorf("unsupported checksum mode %d", mode[0])
+	}
+	return checksumModes[mode[0]], nil
+}
+
+// deviceChecksum returns the SHA-256 of the first size bytes of a device
+func deviceChecksum(r io.ReaderAt, size int64) ([]byte, error) {
+	h := sha256.New()
+	if _, err := io.CopyBuffer(h, io.NewSectionReader(r, 0, size),
+		make([]byte, 16*diskrsync.DefTargetBlockSize)); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// verifySource exchanges the checksums with the target on a new connection,
+// returning the checksums of the source and target and the number of blocks
+// changed on the target
+func verifySource(src io.ReaderAt, size int64, dial func() (net.Conn, error),
+	logger logr.Logger) (string, string, int64, error) {
+	conn, err := dial()
+	if err != nil {
+		return "", "", 0, err
+	}
+	defer conn.Close()
+	header := bytes.NewBufferString(checksumMagic)
+	_ = writeUint64(header, size)
+	if _, err := conn.Write(header.Bytes()); err != nil {
+		return "", "", 0, err
+	}
+
+	// The target computes its checksum at the same time
+	logger.Info("Computing the device checksum")
+	sum, err := deviceChecksum(src, size)
+	if err != nil {
+		return "", "", 0, err
+	}
+	if _, err := conn.Write(sum); err != nil {
+		return "", "", 0, err
+	}
+	targetSum := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(conn, targetSum); err != nil {
+		return "", "", 0, fmt.Errorf("could not read the target checksum: %w", err)
+	}
+	blocksChanged, err := readUint64(conn)
+	if err != nil {
+		return "", "", 0, err
+	}
+	return hex.EncodeToString(sum), hex.EncodeToString(targetSum), blocksChanged, nil
+}
+
+// verifyTarget accepts the connection of the checksum exchange once the
+// transfer has completed, returning the checksums of the source and target
+func verifyTarget(w spgz.SparseFile, accept func() (net.Conn, error), stats *transferStats,
+	logger logr.Logger) (string, string, error) {
+	if accept == nil {
+		return "", "", errors.New("checksums are not supported")
+	}
+	conn, err := accept()
+	if err != nil {
+		return "", "", err
+	}
+	defer conn.Close()
+	magic := make([]byte, len(checksumMagic))
+	if _, err := io.ReadFull(conn, magic); err != nil {
+		return "", "", err
+	}
+	if string(magic) != checksumMagic {
+		return "", "", errors.New("expected the checksum exchange")
+	}
+	size, err := readUint64(conn)
+	if err != nil {
+		return "", "", err
+	}
+	// The target may have grown during the transfer
+	targetSize, err := w.Seek(0, io.SeekEnd)
+	if err != nil {
+		return "", "", err
+	}
+	if size < 0 {
+		return "", "", fmt.Errorf("invalid size %d", size)
+	}
+
+	logger.Info("Computing the device checksum")
+	sum, err := deviceChecksum(w, min(size, targetSize))
+	if err != nil {
+		return "", "", err
+	}
+	reply := bytes.NewBuffer(sum)
+	_ = writeUint64(reply, stats.blocksChanged())
+	if _, err := conn.Write(reply.Bytes()); err != nil {
+		return "", "", err
+	}
+	sourceSum := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(conn, sourceSum); err != nil {
+		return "", "", fmt.Errorf("could not read the source checksum: %w", err)
+	}
+	return hex.EncodeToString(sourceSum), hex.EncodeToString(sum), nil
+}
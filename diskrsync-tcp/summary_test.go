@@ -0,0 +1,172 @@
+/*
+Copyright © 2026 The VolSync authors
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dop251/diskrsync"
+	"github.com/dop251/spgz"
+	"github.com/go-logr/logr"
+)
+
+// corruptingSparseFile drops the first write to the target
+type corruptingSparseFile struct {
+	spgz.SparseFile
+	dropped bool
+}
+
+func (c *corruptingSparseFile) WriteAt(p []byte, off int64) (int, error) {
+	if !c.dropped {
+		c.dropped = true
+		return len(p), nil
+	}
+	return c.SparseFile.WriteAt(p, off)
+}
+
+// syncTransfer syncs src to the target the way startServer accepts the
+// connections
+func syncTransfer(t *testing.T, srcName string, dst spgz.SparseFile, dstSize int64,
+	opts *options) (summary *syncSummary, srcErr, dstErr error) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn, err := listener.Accept()
+		if err != nil {
+			dstErr = err
+			return
+		}
+		defer conn.Close()
+		_, dstErr = receive(dst, dstSize, conn, listener.Accept, false, &options{}, logr.Discard())
+	}()
+
+	dial := func() (net.Conn, error) { return net.Dial("tcp", listener.Addr().String()) }
+	summary, srcErr = syncToTarget(srcName, dial, opts, logr.Discard())
+	<-done
+	return summary, srcErr, dstErr
+}
+
+// checksumTestFiles returns a source and a target that differ in one segment
+func checksumTestFiles(t *testing.T) (string, *os.File, []byte) {
+	t.Helper()
+	dir := t.TempDir()
+	data := make([]byte, testSize)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	base := bytes.Clone(data)
+	if _, err := rand.Read(base[testSegmentSize : 2*testSegmentSize]); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(dir, "src"), data)
+	return filepath.Join(dir, "src"), writeTestFile(t, filepath.Join(dir, "dst"), base), data
+}
+
+func TestSyncSummary(t *testing.T) {
+	for _, streams := range []int{1, 3} {
+		srcName, dst, data := checksumTestFiles(t)
+		opts := &options{checksum: checksumEnforce, streams: streams}
+		summary, srcErr, dstErr := syncTransfer(t, srcName, spgz.NewSparseFileWithFallback(dst), testSize, opts)
+		if srcErr != nil || dstErr != nil {
+			t.Fatalf("transfer with %d streams failed: %v, %v", streams, srcErr, dstErr)
+		}
+		result, err := os.ReadFile(dst.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(result, data) {
+			t.Fatal("target does not match the source")
+		}
+		if summary.SourceSHA256 == "" || summary.SourceSHA256 != summary.TargetSHA256 {
+			t.Fatalf("unexpected checksums %s and %s", summary.SourceSHA256, summary.TargetSHA256)
+		}
+		// The segment that differs is written, with the neighbouring blocks
+		// that share a leaf of the diskrsync tree
+		if summary.BlocksChanged == nil || *summary.BlocksChanged < testSegmentSize/diskrsync.DefTargetBlockSize ||
+			*summary.BlocksChanged >= testSize/diskrsync.DefTargetBlockSize {
+			t.Fatalf("unexpected summary %+v", summary)
+		}
+		if summary.BytesRead < testSize || summary.BytesSent < testSegmentSize {
+			t.Fatalf("unexpected summary %+v", summary)
+		}
+	}
+}
+
+func TestSyncSummaryWithoutChecksum(t *testing.T) {
+	srcName, dst, _ := checksumTestFiles(t)
+	summary, srcErr, dstErr := syncTransfer(t, srcName, spgz.NewSparseFileWithFallback(dst), testSize, &options{})
+	if srcErr != nil || dstErr != nil {
+		t.Fatalf("transfer failed: %v, %v", srcErr, dstErr)
+	}
+	if summary.SourceSHA256 != "" || summary.TargetSHA256 != "" || summary.BlocksChanged != nil {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+}
+
+func TestChecksumMismatch(t *testing.T) {
+	// A report only logs the mismatch
+	srcName, dst, _ := checksumTestFiles(t)
+	target := &corruptingSparseFile{SparseFile: spgz.NewSparseFileWithFallback(dst)}
+	summary, srcErr, dstErr := syncTransfer(t, srcName, target, testSize, &options{checksum: checksumReport})
+	if srcErr != nil || dstErr != nil {
+		t.Fatalf("transfer failed: %v, %v", srcErr, dstErr)
+	}
+	if summary.SourceSHA256 == summary.TargetSHA256 {
+		t.Fatal("expected the checksums to differ")
+	}
+
+	// Both ends fail when the checksums are enforced
+	srcName, dst, _ = checksumTestFiles(t)
+	target = &corruptingSparseFile{SparseFile: spgz.NewSparseFileWithFallback(dst)}
+	_, srcErr, dstErr = syncTransfer(t, srcName, target, testSize, &options{checksum: checksumEnforce})
+	if srcErr == nil || dstErr == nil {
+		t.Fatalf("expected the sync to fail: %v, %v", srcErr, dstErr)
+	}
+}
+
+func TestChecksumMode(t *testing.T) {
+	for _, mode := range checksumModes[1:] {
+		buf := &bytes.Buffer{}
+		if err := requestChecksum(buf, mode); err != nil {
+			t.Fatal(err)
+		}
+		if magic := string(buf.Next(len(checksumMagic))); magic != checksumMagic {
+			t.Fatalf("unexpected magic %q", magic)
+		}
+		if result, err := readChecksumMode(buf); err != nil || result != mode {
+			t.Fatalf("unexpected mode %q: %v", result, err)
+		}
+	}
+	// Nothing is requested without checksums, for compatibility with older
+	// targets
+	buf := &bytes.Buffer{}
+	if err := requestChecksum(buf, checksumNone); err != nil || buf.Len() != 0 {
+		t.Fatalf("unexpected request %q: %v", buf.String(), err)
+	}
+}
@@ -44,6 +44,16 @@ type Mover interface {
 	Cleanup(ctx context.Context) (Result, error)
 }
 
+// Maintainer is an optional interface that data movers can implement to
+// perform periodic work that is independent of synchronization.
+type Maintainer interface {
+	// Maintain begins or continues any maintenance that is due. It is called
+	// on every reconcile, regardless of the synchronization state. Result's
+	// RetryAfter indicates when Maintain should next be called. Must be
+	// idempotent.
+	Maintain(ctx context.Context) (Result, error)
+}
+
 // Result indicates the outcome of a synchronization attempt
 type Result struct {
 	// Completed is set to true if the synchronization has completed. RetryAfter
@@ -131,6 +131,14 @@ func (rb *Builder) FromSource(client client.Client, logger logr.Logger,
 		rcloneConfigSection: source.Spec.Rclone.RcloneConfigSection,
 		rcloneDestPath:      source.Spec.Rclone.RcloneDestPath,
 		rcloneConfig:        source.Spec.Rclone.RcloneConfig,
+		mode:                source.Spec.Rclone.Mode,
+		filters:             source.Spec.Rclone.Filters,
+		filterConfigMap:     source.Spec.Rclone.FilterConfigMap,
+		encryption:          source.Spec.Rclone.Encryption,
+		verify:              source.Spec.Rclone.Verify,
+		tuning:              source.Spec.Rclone.Tuning,
+		blockChunkSize:      source.Spec.Rclone.BlockChunkSize,
+		versioning:          source.Spec.Rclone.Versioning,
 		isSource:            isSource,
 		paused:              source.Spec.Paused,
 		mainPVCName:         &source.Spec.SourcePVC,
@@ -159,6 +167,7 @@ func (rb *Builder) FromDestination(client client.Client, logger logr.Logger,
 		volumehandler.WithRecorder(eventRecorder),
 		volumehandler.WithOwner(destination),
 		volumehandler.FromDestination(&destination.Spec.Rclone.ReplicationDestinationVolumeOptions),
+		volumehandler.VolumeMode(destination.Spec.Rclone.VolumeMode), // Allow setting block mode for dynamic dest PVC
 	)
 	if err != nil {
 		return nil, err
@@ -180,6 +189,14 @@ func (rb *Builder) FromDestination(client client.Client, logger logr.Logger,
 		rcloneConfigSection: destination.Spec.Rclone.RcloneConfigSection,
 		rcloneDestPath:      destination.Spec.Rclone.RcloneDestPath,
 		rcloneConfig:        destination.Spec.Rclone.RcloneConfig,
+		mode:                destination.Spec.Rclone.Mode,
+		filters:             destination.Spec.Rclone.Filters,
+		filterConfigMap:     destination.Spec.Rclone.FilterConfigMap,
+		encryption:          destination.Spec.Rclone.Encryption,
+		verify:              destination.Spec.Rclone.Verify,
+		tuning:              destination.Spec.Rclone.Tuning,
+		blockChunkSize:      destination.Spec.Rclone.BlockChunkSize,
+		restoreVersion:      destination.Spec.Rclone.RestoreVersion,
 		isSource:            isSource,
 		paused:              destination.Spec.Paused,
 		mainPVCName:         destination.Spec.Rclone.DestinationPVC,
@@ -28,6 +28,11 @@ var rcloneRegex = regexp.MustCompile(
 		`^\s*([cC]hecks:)|` +
 		`^\s*([dD]eleted:)|` +
 		`^\s*([eE]lapsed time:)|` +
+		`^\s*(Saving replaced and deleted files in version)|` +
+		`^\s*(Removing version)|` +
+		`^\s*(Restored version)|` +
+		`^\s*(Verification passed)|` +
+		`^\s*((Up|Down)loading block device)|` +
 		`^\s*(Rclone completed in)`)
 
 // Filter rclone log lines for a successful mover job
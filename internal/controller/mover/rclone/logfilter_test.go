@@ -199,4 +199,71 @@ Rclone completed in 5s`
 			Expect(filteredLines).To(Equal(expectedFilteredLog))
 		})
 	})
+
+	Context("Rclone source mover logs with versioning and verification", func() {
+		// nolint:lll
+		versioningLog := `VolSync rclone container version: v0.6.0+39a85b7-dirty
+getfacl: Removing leading '/' from absolute path names
+Saving replaced and deleted files in version 20261019-120000
+2026/10/19 12:00:01 DEBUG : rclone: Version "v1.68.1" starting with parameters ["rclone" "sync" "--one-file-system" "--create-empty-src-dirs" "--backup-dir" "rclone-data-mover:rclone-test/.volsync-versions/20261019-120000" "--filter-from" "/tmp/filter-rules.txt" "/data" "rclone-data-mover:rclone-test" "--log-level" "DEBUG"]
+2026/10/19 12:00:02 INFO  : outfile: Moved into backup dir
+2026/10/19 12:00:02 INFO  : outfile: Copied (new)
+2026/10/19 12:00:02 INFO  :
+Transferred:         699.051 KiB / 699.051 KiB, 100%, 0 B/s, ETA -
+Transferred:            1 / 1, 100%
+Elapsed time:         1.0s
+
+Verifying transfer with rclone check
+2026/10/19 12:00:03 NOTICE: S3 bucket rclone-test: 0 differences found
+2026/10/19 12:00:03 NOTICE: S3 bucket rclone-test: 3 matching files
+Verification passed
+Removing version 20261012-120000
+Rclone completed in 2s`
+
+		expectedFilteredLog := `Saving replaced and deleted files in version 20261019-120000
+Transferred:         699.051 KiB / 699.051 KiB, 100%, 0 B/s, ETA -
+Transferred:            1 / 1, 100%
+Elapsed time:         1.0s
+Verification passed
+Removing version 20261012-120000
+Rclone completed in 2s`
+
+		It("Should filter the logs", func() {
+			reader := strings.NewReader(versioningLog)
+			filteredLines, err := utils.FilterLogs(reader, rclone.LogLineFilterSuccess)
+			Expect(err).NotTo(HaveOccurred())
+
+			logger.Info("Logs after filter", "filteredLines", filteredLines)
+			Expect(filteredLines).To(Equal(expectedFilteredLog))
+		})
+	})
+
+	Context("Rclone mover logs for a block volume", func() {
+		// nolint:lll
+		blockLog := `VolSync rclone container version: v0.6.0+39a85b7-dirty
+Uploading block device (1073741824 bytes)
+2026/10/19 12:00:01 DEBUG : rclone: Version "v1.74.3" starting with parameters ["rclone" "rcat" "--size" "1073741824" "rclone-data-mover:rclone-test/volsync-block.img" "--log-level" "DEBUG"]
+2026/10/19 12:00:31 INFO  : volsync-block.img: Copied (new)
+2026/10/19 12:00:31 INFO  :
+Transferred:            1 GiB / 1 GiB, 100%, 34 MiB/s, ETA 0s
+Transferred:            1 / 1, 100%
+Elapsed time:        30.1s
+
+Rclone completed in 31s`
+
+		expectedFilteredLog := `Uploading block device (1073741824 bytes)
+Transferred:            1 GiB / 1 GiB, 100%, 34 MiB/s, ETA 0s
+Transferred:            1 / 1, 100%
+Elapsed time:        30.1s
+Rclone completed in 31s`
+
+		It("Should filter the logs", func() {
+			reader := strings.NewReader(blockLog)
+			filteredLines, err := utils.FilterLogs(reader, rclone.LogLineFilterSuccess)
+			Expect(err).NotTo(HaveOccurred())
+
+			logger.Info("Logs after filter", "filteredLines", filteredLines)
+			Expect(filteredLines).To(Equal(expectedFilteredLog))
+		})
+	})
 })
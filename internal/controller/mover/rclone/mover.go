@@ -22,12 +22,17 @@ package rclone
 import (
 	"context"
 	"errors"
+	"fmt"
 	"path"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
@@ -43,12 +48,28 @@ import (
 
 const (
 	mountPath         = "/data"
+	devicePath        = "/dev/block"
 	dataVolumeName    = "data"
 	rcloneSecret      = "rclone-secret"
 	rcloneCAMountPath = "/customCA"
 	rcloneCAFilename  = "ca.crt"
+	filterVolumeName  = "rclone-filters"
+	filterMountPath   = "/rclone-filters"
+	filterFilename    = "filter.txt"
 )
 
+// Filter rules either include (+) or exclude (-) a pattern, or clear (!) the
+// rules that precede them
+var filterRuleRegex = regexp.MustCompile(`^([+-] \S.*|!)$`)
+
+// Bandwidth limits are a rate (optionally separate upload:download rates) or a
+// timetable of space separated [Day-]HH:MM,rate entries
+const bwRate = `(off|\d+(\.\d+)?[BbKkMmGgTtPp]?)(:(off|\d+(\.\d+)?[BbKkMmGgTtPp]?))?`
+const bwTimetableEntry = `((Mon|Tue|Wed|Thu|Fri|Sat|Sun)-)?([01]\d|2[0-3]):[0-5]\d,` + bwRate
+
+var bandwidthLimitRegex = regexp.MustCompile(
+	`^(` + bwRate + `|` + bwTimetableEntry + `( +` + bwTimetableEntry + `)*)$`)
+
 // Mover is the reconciliation logic for the Rclone-based data mover.
 type Mover struct {
 	client              client.Client
@@ -61,6 +82,13 @@ type Mover struct {
 	rcloneConfigSection *string
 	rcloneDestPath      *string
 	rcloneConfig        *string
+	mode                *volsyncv1alpha1.RcloneModeType
+	filters             []string
+	filterConfigMap     *volsyncv1alpha1.ConfigMapKeySpec
+	encryption          *volsyncv1alpha1.RcloneEncryptionSpec
+	verify              *string
+	tuning              *volsyncv1alpha1.RcloneTuningSpec
+	blockChunkSize      *resource.Quantity
 	isSource            bool
 	paused              bool
 	mainPVCName         *string
@@ -69,8 +97,11 @@ type Mover struct {
 	latestMoverStatus   *volsyncv1alpha1.MoverStatus
 	moverConfig         volsyncv1alpha1.MoverConfig
 	moverVolumes        []volsyncv1alpha1.MoverVolume
+	// Source-only fields
+	versioning *volsyncv1alpha1.RcloneVersioningSpec
 	// Destination-only fields
 	cleanupTempPVC bool
+	restoreVersion *string
 }
 
 var _ mover.Mover = &Mover{}
@@ -110,6 +141,10 @@ func (m *Mover) Synchronize(ctx context.Context) (mover.Result, error) {
 		return mover.InProgress(), err
 	}
 
+	if err := m.validateBlockOptions(dataPVC); err != nil {
+		return mover.InProgress(), err
+	}
+
 	// Prepare ServiceAccount, role, rolebinding
 	sa, err := m.saHandler.Reconcile(ctx, m.logger)
 	if sa == nil || err != nil {
@@ -124,6 +159,16 @@ func (m *Mover) Synchronize(ctx context.Context) (mover.Result, error) {
 		return mover.InProgress(), err
 	}
 
+	// Validate filter ConfigMap if in spec
+	if err := m.validateFilterConfigMap(ctx); err != nil {
+		return mover.InProgress(), err
+	}
+
+	// Validate encryption Secret if in spec
+	if err := m.validateEncryptionSecret(ctx); err != nil {
+		return mover.InProgress(), err
+	}
+
 	// Validate MoverVolumes
 	err = utils.ValidateMoverVolumes(ctx, m.client, m.logger, m.owner.GetNamespace(), m.moverVolumes)
 	if err != nil {
@@ -223,6 +268,7 @@ func (m *Mover) ensureJob(ctx context.Context, dataPVC *corev1.PersistentVolumeC
 	direction := "destination"
 
 	readOnlyVolume := false
+	blockVolume := utils.PvcIsBlockMode(dataPVC)
 	if m.isSource {
 		dir = "src"
 		direction = "source"
@@ -267,6 +313,34 @@ func (m *Mover) ensureJob(ctx context.Context, dataPVC *corev1.PersistentVolumeC
 			{Name: "DIRECTION", Value: direction},
 			{Name: "MOUNT_PATH", Value: mountPath},
 			{Name: "RCLONE_CONFIG_SECTION", Value: *m.rcloneConfigSection},
+			{Name: "RCLONE_MODE", Value: string(m.getMode())},
+		}
+		if m.encryption != nil {
+			defaultEnvVars = append(defaultEnvVars, m.encryptionEnvVars()...)
+		}
+		if m.versioning != nil {
+			defaultEnvVars = append(defaultEnvVars, versioningEnvVars(m.versioning)...)
+		}
+		if m.verify != nil {
+			defaultEnvVars = append(defaultEnvVars,
+				corev1.EnvVar{Name: "RCLONE_VERIFY", Value: *m.verify},
+				corev1.EnvVar{Name: "RCLONE_VERIFY_COMMAND", Value: m.verifyCommand()})
+		}
+		if m.blockChunkSize != nil {
+			defaultEnvVars = append(defaultEnvVars, corev1.EnvVar{
+				Name: "BLOCK_CHUNK_SIZE", Value: fmt.Sprintf("%dB", m.blockChunkSize.Value()),
+			})
+		}
+		// Set after the RCLONE_ env vars from the secret so the spec takes
+		// precedence
+		defaultEnvVars = append(defaultEnvVars, tuningEnvVars(m.tuning)...)
+		if m.restoreVersion != nil {
+			defaultEnvVars = append(defaultEnvVars, corev1.EnvVar{Name: "RESTORE_VERSION", Value: *m.restoreVersion})
+		}
+		if len(m.filters) > 0 {
+			defaultEnvVars = append(defaultEnvVars, corev1.EnvVar{
+				Name: "RCLONE_FILTER_RULES", Value: strings.Join(m.filters, "\n"),
+			})
 		}
 
 		// Add our defaults after RCLONE_ env vars so any duplicates will be
@@ -293,11 +367,20 @@ func (m *Mover) ensureJob(ctx context.Context, dataPVC *corev1.PersistentVolumeC
 				ReadOnlyRootFilesystem: ptr.To(true),
 			},
 			VolumeMounts: []corev1.VolumeMount{
-				{Name: dataVolumeName, MountPath: mountPath},
 				{Name: rcloneSecret, MountPath: "/rclone-config/"},
 				{Name: "tempdir", MountPath: "/tmp"},
 			},
 		}}
+		if blockVolume {
+			// Block volumes are transferred as a single image file on the remote
+			job.Spec.Template.Spec.Containers[0].VolumeDevices = []corev1.VolumeDevice{
+				{Name: dataVolumeName, DevicePath: devicePath},
+			}
+		} else {
+			job.Spec.Template.Spec.Containers[0].VolumeMounts = append([]corev1.VolumeMount{
+				{Name: dataVolumeName, MountPath: mountPath},
+			}, job.Spec.Template.Spec.Containers[0].VolumeMounts...)
+		}
 		job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
 		job.Spec.Template.Spec.ServiceAccountName = sa.Name
 		job.Spec.Template.Spec.Volumes = []corev1.Volume{
@@ -350,6 +433,30 @@ func (m *Mover) ensureJob(ctx context.Context, dataPVC *corev1.PersistentVolumeC
 			})
 		}
 
+		if m.filterConfigMap != nil {
+			// Tell mover where to find the filter file
+			podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, corev1.EnvVar{
+				Name:  "RCLONE_FILTER_FROM",
+				Value: path.Join(filterMountPath, filterFilename),
+			})
+			podSpec.Containers[0].VolumeMounts =
+				append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+					Name:      filterVolumeName,
+					MountPath: filterMountPath,
+				})
+			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+				Name: filterVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: m.filterConfigMap.ConfigMapName},
+						Items: []corev1.KeyToPath{
+							{Key: m.filterConfigMap.Key, Path: filterFilename},
+						},
+					},
+				},
+			})
+		}
+
 		// Update the job securityContext, podLabels and resourceRequirements from moverConfig (if specified)
 		utils.UpdatePodTemplateSpecFromMoverConfig(&job.Spec.Template, m.moverConfig, corev1.ResourceRequirements{})
 
@@ -427,10 +534,226 @@ func (m *Mover) validateSpec() error {
 		m.logger.Error(err, "Rclone Spec validation error")
 		return err
 	}
+	switch m.getMode() {
+	case volsyncv1alpha1.RcloneModeSync, volsyncv1alpha1.RcloneModeCopy:
+	case volsyncv1alpha1.RcloneModeMove, volsyncv1alpha1.RcloneModeBisync:
+		// On a destination these modes would delete or modify the data in the
+		// remote that the source replicates to
+		if !m.isSource {
+			err := fmt.Errorf("rclone mode %s is not supported on a ReplicationDestination", m.getMode())
+			m.logger.Error(err, "Rclone Spec validation error")
+			return err
+		}
+		// These modes modify the origin, which is pointless on a point-in-time
+		// copy of the source volume
+		if !m.vh.IsCopyMethodDirect() {
+			err := fmt.Errorf("rclone mode %s requires copyMethod Direct", m.getMode())
+			m.logger.Error(err, "Rclone Spec validation error")
+			return err
+		}
+	default:
+		err := fmt.Errorf("unknown rclone mode: %s", m.getMode())
+		m.logger.Error(err, "Rclone Spec validation error")
+		return err
+	}
+	for _, rule := range m.filters {
+		if !filterRuleRegex.MatchString(rule) {
+			err := fmt.Errorf("invalid rclone filter rule: %q", rule)
+			m.logger.Error(err, "Rclone Spec validation error")
+			return err
+		}
+	}
+	if m.filterConfigMap != nil &&
+		(len(m.filterConfigMap.ConfigMapName) == 0 || len(m.filterConfigMap.Key) == 0) {
+		err := errors.New("filterConfigMap requires both configMapName and key")
+		m.logger.Error(err, "Rclone Spec validation error")
+		return err
+	}
+	if (m.versioning != nil || m.restoreVersion != nil) && m.getMode() == volsyncv1alpha1.RcloneModeBisync {
+		err := errors.New("rclone versioning is not supported with mode bisync")
+		m.logger.Error(err, "Rclone Spec validation error")
+		return err
+	}
+	if m.verify != nil && m.getMode() == volsyncv1alpha1.RcloneModeMove {
+		err := errors.New("rclone verify is not supported with mode move")
+		m.logger.Error(err, "Rclone Spec validation error")
+		return err
+	}
+	if m.verify != nil && m.restoreVersion != nil {
+		err := errors.New("rclone verify is not supported with restoreVersion")
+		m.logger.Error(err, "Rclone Spec validation error")
+		return err
+	}
+	if m.blockChunkSize != nil && m.blockChunkSize.Sign() <= 0 {
+		err := errors.New("rclone blockChunkSize must be positive")
+		m.logger.Error(err, "Rclone Spec validation error")
+		return err
+	}
+	if m.tuning != nil && m.tuning.BandwidthLimit != nil &&
+		!bandwidthLimitRegex.MatchString(*m.tuning.BandwidthLimit) {
+		err := fmt.Errorf("invalid rclone bandwidthLimit: %q", *m.tuning.BandwidthLimit)
+		m.logger.Error(err, "Rclone Spec validation error")
+		return err
+	}
+	if m.encryption != nil && len(m.encryption.SecretName) == 0 {
+		err := errors.New("unable to get Rclone encryption secret name")
+		m.logger.Error(err, "Rclone Spec validation error")
+		return err
+	}
 	m.logger.V(1).Info("Rclone Spec validation complete.")
 	return nil
 }
 
+func (m *Mover) getMode() volsyncv1alpha1.RcloneModeType {
+	if m.mode == nil {
+		return volsyncv1alpha1.RcloneModeSync
+	}
+	return *m.mode
+}
+
+// validateBlockOptions checks that only the options that apply to the image
+// of a block volume are used with one
+func (m *Mover) validateBlockOptions(dataPVC *corev1.PersistentVolumeClaim) error {
+	if !utils.PvcIsBlockMode(dataPVC) {
+		return nil
+	}
+	var err error
+	switch {
+	case m.getMode() != volsyncv1alpha1.RcloneModeSync && m.getMode() != volsyncv1alpha1.RcloneModeCopy:
+		err = fmt.Errorf("rclone mode %s is not supported with block volumes", m.getMode())
+	case len(m.filters) > 0 || m.filterConfigMap != nil:
+		err = errors.New("rclone filters are not supported with block volumes")
+	case m.versioning != nil || m.restoreVersion != nil:
+		err = errors.New("rclone versioning is not supported with block volumes")
+	case m.verify != nil:
+		err = errors.New("rclone verify is not supported with block volumes")
+	}
+	if err != nil {
+		m.logger.Error(err, "Rclone Spec validation error")
+	}
+	return err
+}
+
+func (m *Mover) validateFilterConfigMap(ctx context.Context) error {
+	if m.filterConfigMap == nil {
+		return nil
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.filterConfigMap.ConfigMapName,
+			Namespace: m.owner.GetNamespace(),
+		},
+	}
+	logger := m.logger.WithValues("filterConfigMap", client.ObjectKeyFromObject(configMap))
+	return utils.GetAndValidateConfigMap(ctx, m.client, logger, configMap, m.filterConfigMap.Key)
+}
+
+func (m *Mover) validateEncryptionSecret(ctx context.Context) error {
+	if m.encryption == nil {
+		return nil
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.encryption.SecretName,
+			Namespace: m.owner.GetNamespace(),
+		},
+	}
+	logger := m.logger.WithValues("encryption Secret", client.ObjectKeyFromObject(secret))
+	if err := utils.GetAndValidateSecret(ctx, m.client, logger, secret, "password"); err != nil {
+		logger.Error(err, "Rclone encryption secret does not contain the proper fields")
+		return err
+	}
+	return nil
+}
+
+// encryptionEnvVars returns the env vars used by the mover to layer a crypt
+// remote over the configured remote
+func (m *Mover) encryptionEnvVars() []corev1.EnvVar {
+	filenameEncryption := "standard"
+	if m.encryption.FilenameEncryption != nil {
+		filenameEncryption = *m.encryption.FilenameEncryption
+	}
+	directoryNameEncryption := true
+	if m.encryption.DirectoryNameEncryption != nil {
+		directoryNameEncryption = *m.encryption.DirectoryNameEncryption
+	}
+	return []corev1.EnvVar{
+		cryptEnvFromSecret("CRYPT_PASSWORD", m.encryption.SecretName, "password", false),
+		cryptEnvFromSecret("CRYPT_SALT", m.encryption.SecretName, "salt", true),
+		{Name: "CRYPT_FILENAME_ENCRYPTION", Value: filenameEncryption},
+		{Name: "CRYPT_DIRECTORY_NAME_ENCRYPTION", Value: strconv.FormatBool(directoryNameEncryption)},
+	}
+}
+
+// verifyCommand returns the rclone command used to verify the transfer. rclone
+// check can't compare the hashes of the files on the volume with those of the
+// encrypted files, so it would only compare the sizes through a crypt remote.
+func (m *Mover) verifyCommand() string {
+	if m.encryption != nil && m.verify != nil && *m.verify == "hash" {
+		return "cryptcheck"
+	}
+	return "check"
+}
+
+// tuningEnvVars returns the rclone env vars corresponding to the tuning
+// options that are set
+func tuningEnvVars(tuning *volsyncv1alpha1.RcloneTuningSpec) []corev1.EnvVar {
+	envVars := []corev1.EnvVar{}
+	if tuning == nil {
+		return envVars
+	}
+	if tuning.Transfers != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: "RCLONE_TRANSFERS", Value: strconv.Itoa(int(*tuning.Transfers)),
+		})
+	}
+	if tuning.Checkers != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: "RCLONE_CHECKERS", Value: strconv.Itoa(int(*tuning.Checkers)),
+		})
+	}
+	if tuning.BandwidthLimit != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: "RCLONE_BWLIMIT", Value: *tuning.BandwidthLimit})
+	}
+	if tuning.MultiThreadStreams != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: "RCLONE_MULTI_THREAD_STREAMS", Value: strconv.Itoa(int(*tuning.MultiThreadStreams)),
+		})
+	}
+	if tuning.FastList != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: "RCLONE_FAST_LIST", Value: strconv.FormatBool(*tuning.FastList),
+		})
+	}
+	if tuning.Checksum != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: "RCLONE_CHECKSUM", Value: strconv.FormatBool(*tuning.Checksum),
+		})
+	}
+	return envVars
+}
+
+func versioningEnvVars(versioning *volsyncv1alpha1.RcloneVersioningSpec) []corev1.EnvVar {
+	envVars := []corev1.EnvVar{{Name: "RCLONE_VERSIONING", Value: "1"}}
+	if versioning.Keep != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: "VERSIONS_KEEP", Value: strconv.Itoa(int(*versioning.Keep)),
+		})
+	}
+	if versioning.MaxAgeDays != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: "VERSIONS_MAX_AGE_DAYS", Value: strconv.Itoa(int(*versioning.MaxAgeDays)),
+		})
+	}
+	return envVars
+}
+
+func cryptEnvFromSecret(name string, secretName string, field string, optional bool) corev1.EnvVar {
+	envVar := utils.EnvFromSecret(secretName, field, optional)
+	envVar.Name = name
+	return envVar
+}
+
 func (m *Mover) validateRcloneConfig(ctx context.Context) (*corev1.Secret, error) {
 	// Validate user provided rcloneConfig Secret exists and has the proper field
 	secret := &corev1.Secret{
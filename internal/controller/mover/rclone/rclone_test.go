@@ -225,6 +225,57 @@ var _ = Describe("Rclone ignores other movers", func() {
 	})
 })
 
+var _ = Describe("Rclone tuning options", func() {
+	Context("When tuning is omitted", func() {
+		It("has no env vars", func() {
+			Expect(tuningEnvVars(nil)).To(BeEmpty())
+		})
+	})
+	Context("When tuning is specified", func() {
+		It("has env vars that correspond", func() {
+			tuning := &volsyncv1alpha1.RcloneTuningSpec{
+				Transfers:          ptr.To[int32](16),
+				Checkers:           ptr.To[int32](32),
+				BandwidthLimit:     ptr.To("10M:1M"),
+				MultiThreadStreams: ptr.To[int32](0),
+				FastList:           ptr.To(true),
+				Checksum:           ptr.To(false),
+			}
+			Expect(tuningEnvVars(tuning)).To(ConsistOf(
+				corev1.EnvVar{Name: "RCLONE_TRANSFERS", Value: "16"},
+				corev1.EnvVar{Name: "RCLONE_CHECKERS", Value: "32"},
+				corev1.EnvVar{Name: "RCLONE_BWLIMIT", Value: "10M:1M"},
+				corev1.EnvVar{Name: "RCLONE_MULTI_THREAD_STREAMS", Value: "0"},
+				corev1.EnvVar{Name: "RCLONE_FAST_LIST", Value: "true"},
+				corev1.EnvVar{Name: "RCLONE_CHECKSUM", Value: "false"},
+			))
+		})
+		It("only has the env vars that are set", func() {
+			tuning := &volsyncv1alpha1.RcloneTuningSpec{
+				Transfers: ptr.To[int32](4),
+			}
+			Expect(tuningEnvVars(tuning)).To(Equal([]corev1.EnvVar{{Name: "RCLONE_TRANSFERS", Value: "4"}}))
+		})
+	})
+	DescribeTable("bandwidth limits",
+		func(limit string, valid bool) {
+			Expect(bandwidthLimitRegex.MatchString(limit)).To(Equal(valid))
+		},
+		Entry("a rate", "10M", true),
+		Entry("a fractional rate", "1.5M", true),
+		Entry("a rate in bytes", "512000", true),
+		Entry("separate upload and download rates", "10M:100k", true),
+		Entry("off", "off", true),
+		Entry("a timetable", "08:00,512k 12:00,10M 18:00,off", true),
+		Entry("a timetable with days", "Mon-00:00,512 Fri-23:59,10M:off Sun-20:00,1M", true),
+		Entry("an unknown unit", "10X", false),
+		Entry("an invalid time", "25:00,1M", false),
+		Entry("a timetable entry without a rate", "08:00,", false),
+		Entry("an unknown day", "Any-08:00,1M", false),
+		Entry("an empty value", "", false),
+	)
+})
+
 var _ = Describe("Rclone as a source", func() {
 	var ns *corev1.Namespace
 	logger := zap.New(zap.UseDevMode(true), zap.WriteTo(GinkgoWriter))
@@ -346,6 +397,118 @@ var _ = Describe("Rclone as a source", func() {
 					Expect(err.Error()).To(ContainSubstring("Rclone destination"))
 				})
 			})
+			When("transfer options are specified", func() {
+				BeforeEach(func() {
+					rs.Spec.Rclone.RcloneConfig = &testRcloneConfig
+					rs.Spec.Rclone.RcloneConfigSection = &testRcloneConfigSection
+					rs.Spec.Rclone.RcloneDestPath = &testRcloneDestPath
+				})
+				When("mode is copy", func() {
+					BeforeEach(func() {
+						rs.Spec.Rclone.Mode = ptr.To(volsyncv1alpha1.RcloneModeCopy)
+					})
+					It("validation should pass", func() {
+						Expect(mover.validateSpec()).To(Succeed())
+						Expect(mover.getMode()).To(Equal(volsyncv1alpha1.RcloneModeCopy))
+					})
+				})
+				When("mode is move and the copyMethod is Snapshot", func() {
+					BeforeEach(func() {
+						rs.Spec.Rclone.Mode = ptr.To(volsyncv1alpha1.RcloneModeMove)
+						rs.Spec.Rclone.CopyMethod = volsyncv1alpha1.CopyMethodSnapshot
+					})
+					It("validation should fail", func() {
+						err := mover.validateSpec()
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("requires copyMethod Direct"))
+					})
+				})
+				When("mode is move and the copyMethod is Clone", func() {
+					BeforeEach(func() {
+						rs.Spec.Rclone.Mode = ptr.To(volsyncv1alpha1.RcloneModeMove)
+						rs.Spec.Rclone.CopyMethod = volsyncv1alpha1.CopyMethodClone
+					})
+					It("validation should fail", func() {
+						err := mover.validateSpec()
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("requires copyMethod Direct"))
+					})
+				})
+				When("mode is move and the copyMethod is Direct", func() {
+					BeforeEach(func() {
+						rs.Spec.Rclone.Mode = ptr.To(volsyncv1alpha1.RcloneModeMove)
+						rs.Spec.Rclone.CopyMethod = volsyncv1alpha1.CopyMethodDirect
+					})
+					It("validation should pass", func() {
+						Expect(mover.validateSpec()).To(Succeed())
+					})
+				})
+				When("an invalid filter rule is specified", func() {
+					BeforeEach(func() {
+						rs.Spec.Rclone.Filters = []string{"- *.tmp", "*.log"}
+					})
+					It("validation should fail", func() {
+						err := mover.validateSpec()
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("invalid rclone filter rule"))
+					})
+				})
+				When("encryption is specified without a secret", func() {
+					BeforeEach(func() {
+						rs.Spec.Rclone.Encryption = &volsyncv1alpha1.RcloneEncryptionSpec{}
+					})
+					It("validation should fail", func() {
+						err := mover.validateSpec()
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("encryption secret name"))
+					})
+				})
+				When("versioning is used with mode bisync", func() {
+					BeforeEach(func() {
+						rs.Spec.Rclone.Mode = ptr.To(volsyncv1alpha1.RcloneModeBisync)
+						rs.Spec.Rclone.CopyMethod = volsyncv1alpha1.CopyMethodDirect
+						rs.Spec.Rclone.Versioning = &volsyncv1alpha1.RcloneVersioningSpec{}
+					})
+					It("validation should fail", func() {
+						err := mover.validateSpec()
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("not supported with mode bisync"))
+					})
+				})
+				When("verify is used with mode move", func() {
+					BeforeEach(func() {
+						rs.Spec.Rclone.Mode = ptr.To(volsyncv1alpha1.RcloneModeMove)
+						rs.Spec.Rclone.CopyMethod = volsyncv1alpha1.CopyMethodDirect
+						rs.Spec.Rclone.Verify = ptr.To("hash")
+					})
+					It("validation should fail", func() {
+						err := mover.validateSpec()
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("verify is not supported with mode move"))
+					})
+				})
+				When("an invalid bandwidthLimit is specified", func() {
+					BeforeEach(func() {
+						rs.Spec.Rclone.Tuning = &volsyncv1alpha1.RcloneTuningSpec{
+							BandwidthLimit: ptr.To("fast"),
+						}
+					})
+					It("validation should fail", func() {
+						err := mover.validateSpec()
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("invalid rclone bandwidthLimit"))
+					})
+				})
+				When("mode is bisync and the copyMethod is Direct", func() {
+					BeforeEach(func() {
+						rs.Spec.Rclone.Mode = ptr.To(volsyncv1alpha1.RcloneModeBisync)
+						rs.Spec.Rclone.CopyMethod = volsyncv1alpha1.CopyMethodDirect
+					})
+					It("validation should pass", func() {
+						Expect(mover.validateSpec()).To(Succeed())
+					})
+				})
+			})
 		})
 		Context("validate rclone config secret", func() {
 			var rcloneConfigSecret *corev1.Secret
@@ -1337,6 +1500,185 @@ var _ = Describe("Rclone as a source", func() {
 					})
 				})
 
+				When("filters are supplied", func() {
+					BeforeEach(func() {
+						rs.Spec.Rclone.Filters = []string{"- *.tmp", "+ /data/**"}
+						rs.Spec.Rclone.FilterConfigMap = &volsyncv1alpha1.ConfigMapKeySpec{
+							ConfigMapName: caConfigMap.Name,
+							Key:           "key",
+						}
+					})
+					It("should pass the rules and mount the filter file", func() {
+						j, e := mover.ensureJob(ctx, sPVC, sa, rcloneConfigSecret, nil) // Using sPVC as dataPVC (i.e. direct)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(j).To(BeNil()) // hasn't completed
+						job = &batchv1.Job{}
+						Expect(k8sClient.Get(ctx, types.NamespacedName{Name: jobName, Namespace: ns.Name}, job)).To(Succeed())
+
+						env := job.Spec.Template.Spec.Containers[0].Env
+						validateEnvVar(env, "RCLONE_FILTER_RULES", "- *.tmp\n+ /data/**")
+						validateEnvVar(env, "RCLONE_FILTER_FROM", filterMountPath+"/"+filterFilename)
+
+						var filterVol *corev1.Volume
+						for i, v := range job.Spec.Template.Spec.Volumes {
+							if v.Name == filterVolumeName {
+								filterVol = &job.Spec.Template.Spec.Volumes[i]
+							}
+						}
+						Expect(filterVol).NotTo(BeNil())
+						Expect(filterVol.ConfigMap).NotTo(BeNil())
+						Expect(filterVol.ConfigMap.Name).To(Equal(caConfigMap.Name))
+						Expect(filterVol.ConfigMap.Items).To(Equal([]corev1.KeyToPath{{Key: "key", Path: filterFilename}}))
+						Expect(job.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(
+							corev1.VolumeMount{Name: filterVolumeName, MountPath: filterMountPath}))
+					})
+				})
+
+				When("encryption is enabled", func() {
+					BeforeEach(func() {
+						rs.Spec.Rclone.Encryption = &volsyncv1alpha1.RcloneEncryptionSpec{
+							SecretName:         "crypt-secret",
+							FilenameEncryption: ptr.To("obfuscate"),
+						}
+					})
+					It("should pass the crypt settings to the mover", func() {
+						j, e := mover.ensureJob(ctx, sPVC, sa, rcloneConfigSecret, nil) // Using sPVC as dataPVC (i.e. direct)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(j).To(BeNil()) // hasn't completed
+						job = &batchv1.Job{}
+						Expect(k8sClient.Get(ctx, types.NamespacedName{Name: jobName, Namespace: ns.Name}, job)).To(Succeed())
+
+						env := job.Spec.Template.Spec.Containers[0].Env
+						validateEnvVar(env, "CRYPT_FILENAME_ENCRYPTION", "obfuscate")
+						validateEnvVar(env, "CRYPT_DIRECTORY_NAME_ENCRYPTION", "true")
+
+						var password, salt *corev1.EnvVar
+						for i, e := range env {
+							switch e.Name {
+							case "CRYPT_PASSWORD":
+								password = &env[i]
+							case "CRYPT_SALT":
+								salt = &env[i]
+							}
+						}
+						Expect(password).NotTo(BeNil())
+						Expect(password.ValueFrom.SecretKeyRef.Name).To(Equal("crypt-secret"))
+						Expect(password.ValueFrom.SecretKeyRef.Key).To(Equal("password"))
+						Expect(*password.ValueFrom.SecretKeyRef.Optional).To(BeFalse())
+						Expect(salt).NotTo(BeNil())
+						Expect(salt.ValueFrom.SecretKeyRef.Key).To(Equal("salt"))
+						Expect(*salt.ValueFrom.SecretKeyRef.Optional).To(BeTrue())
+					})
+				})
+
+				When("the source PVC is a block device", func() {
+					var blockPVC *corev1.PersistentVolumeClaim
+					BeforeEach(func() {
+						blockPVC = &corev1.PersistentVolumeClaim{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "sblock",
+								Namespace: ns.Name,
+							},
+						}
+						sPVC.Spec.DeepCopyInto(&blockPVC.Spec)
+						blockPVC.Spec.VolumeMode = ptr.To(corev1.PersistentVolumeBlock)
+						rs.Spec.Rclone.BlockChunkSize = ptr.To(resource.MustParse("1Gi"))
+					})
+					JustBeforeEach(func() {
+						Expect(k8sClient.Create(ctx, blockPVC)).To(Succeed())
+					})
+					It("Should attach the data volume as a device", func() {
+						Expect(mover.validateBlockOptions(blockPVC)).To(Succeed())
+						j, e := mover.ensureJob(ctx, blockPVC, sa, rcloneConfigSecret, nil)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(j).To(BeNil()) // hasn't completed
+						job = &batchv1.Job{}
+						Expect(k8sClient.Get(ctx, types.NamespacedName{Name: jobName, Namespace: ns.Name}, job)).To(Succeed())
+
+						c := job.Spec.Template.Spec.Containers[0]
+						for _, volMount := range c.VolumeMounts {
+							Expect(volMount.Name).NotTo(Equal(dataVolumeName))
+						}
+						Expect(c.VolumeDevices).To(HaveLen(1))
+						Expect(c.VolumeDevices[0].Name).To(Equal(dataVolumeName))
+						Expect(c.VolumeDevices[0].DevicePath).To(Equal(devicePath))
+						validateEnvVar(c.Env, "BLOCK_CHUNK_SIZE", "1073741824B")
+					})
+					When("filters are specified", func() {
+						BeforeEach(func() {
+							rs.Spec.Rclone.Filters = []string{"- *.tmp"}
+						})
+						It("Should be rejected", func() {
+							err := mover.validateBlockOptions(blockPVC)
+							Expect(err).To(HaveOccurred())
+							Expect(err.Error()).To(ContainSubstring("not supported with block volumes"))
+						})
+					})
+				})
+
+				When("verify is set", func() {
+					BeforeEach(func() {
+						rs.Spec.Rclone.Verify = ptr.To("size")
+					})
+					It("should pass the verification to the mover", func() {
+						j, e := mover.ensureJob(ctx, sPVC, sa, rcloneConfigSecret, nil) // Using sPVC as dataPVC (i.e. direct)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(j).To(BeNil()) // hasn't completed
+						job = &batchv1.Job{}
+						Expect(k8sClient.Get(ctx, types.NamespacedName{Name: jobName, Namespace: ns.Name}, job)).To(Succeed())
+
+						validateEnvVar(job.Spec.Template.Spec.Containers[0].Env, "RCLONE_VERIFY", "size")
+						validateEnvVar(job.Spec.Template.Spec.Containers[0].Env, "RCLONE_VERIFY_COMMAND", "check")
+					})
+					When("encryption is enabled", func() {
+						BeforeEach(func() {
+							rs.Spec.Rclone.Encryption = &volsyncv1alpha1.RcloneEncryptionSpec{
+								SecretName: "crypt-secret",
+							}
+						})
+						It("should compare the sizes with rclone check", func() {
+							Expect(mover.verifyCommand()).To(Equal("check"))
+						})
+						When("verify is hash", func() {
+							BeforeEach(func() {
+								rs.Spec.Rclone.Verify = ptr.To("hash")
+							})
+							It("should compare the hashes with rclone cryptcheck", func() {
+								j, e := mover.ensureJob(ctx, sPVC, sa, rcloneConfigSecret, nil) // Using sPVC as dataPVC (i.e. direct)
+								Expect(e).NotTo(HaveOccurred())
+								Expect(j).To(BeNil()) // hasn't completed
+								job = &batchv1.Job{}
+								Expect(k8sClient.Get(ctx, types.NamespacedName{Name: jobName, Namespace: ns.Name}, job)).To(Succeed())
+
+								validateEnvVar(job.Spec.Template.Spec.Containers[0].Env, "RCLONE_VERIFY", "hash")
+								validateEnvVar(job.Spec.Template.Spec.Containers[0].Env, "RCLONE_VERIFY_COMMAND", "cryptcheck")
+							})
+						})
+					})
+				})
+
+				When("versioning is enabled", func() {
+					BeforeEach(func() {
+						rs.Spec.Rclone.Versioning = &volsyncv1alpha1.RcloneVersioningSpec{
+							Keep: ptr.To[int32](5),
+						}
+					})
+					It("should pass the retention to the mover", func() {
+						j, e := mover.ensureJob(ctx, sPVC, sa, rcloneConfigSecret, nil) // Using sPVC as dataPVC (i.e. direct)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(j).To(BeNil()) // hasn't completed
+						job = &batchv1.Job{}
+						Expect(k8sClient.Get(ctx, types.NamespacedName{Name: jobName, Namespace: ns.Name}, job)).To(Succeed())
+
+						env := job.Spec.Template.Spec.Containers[0].Env
+						validateEnvVar(env, "RCLONE_VERSIONING", "1")
+						validateEnvVar(env, "VERSIONS_KEEP", "5")
+						for _, e := range env {
+							Expect(e.Name).NotTo(Equal("VERSIONS_MAX_AGE_DAYS"))
+						}
+					})
+				})
+
 				Context("Cluster wide proxy settings", func() {
 					When("no proxy env vars are set on the volsync controller", func() {
 						It("shouldn't set any proxy env vars on the mover job", func() {
@@ -1789,6 +2131,47 @@ var _ = Describe("Rclone as a destination", func() {
 			Expect(mover).NotTo(BeNil())
 		})
 
+		Context("validate rclone spec", func() {
+			When("mode is move", func() {
+				BeforeEach(func() {
+					rd.Spec.Rclone.RcloneConfig = &testRcloneConfig
+					rd.Spec.Rclone.RcloneConfigSection = &testRcloneConfigSection
+					rd.Spec.Rclone.RcloneDestPath = &testRcloneDestPath
+					rd.Spec.Rclone.Mode = ptr.To(volsyncv1alpha1.RcloneModeMove)
+				})
+				It("validation should fail", func() {
+					// Moving would delete the data from the remote
+					err := mover.validateSpec()
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("not supported on a ReplicationDestination"))
+				})
+			})
+			When("mode is bisync", func() {
+				BeforeEach(func() {
+					rd.Spec.Rclone.RcloneConfig = &testRcloneConfig
+					rd.Spec.Rclone.RcloneConfigSection = &testRcloneConfigSection
+					rd.Spec.Rclone.RcloneDestPath = &testRcloneDestPath
+					rd.Spec.Rclone.Mode = ptr.To(volsyncv1alpha1.RcloneModeBisync)
+				})
+				It("validation should fail", func() {
+					err := mover.validateSpec()
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("not supported on a ReplicationDestination"))
+				})
+			})
+			When("mode is copy", func() {
+				BeforeEach(func() {
+					rd.Spec.Rclone.RcloneConfig = &testRcloneConfig
+					rd.Spec.Rclone.RcloneConfigSection = &testRcloneConfigSection
+					rd.Spec.Rclone.RcloneDestPath = &testRcloneDestPath
+					rd.Spec.Rclone.Mode = ptr.To(volsyncv1alpha1.RcloneModeCopy)
+				})
+				It("validation should pass", func() {
+					Expect(mover.validateSpec()).To(Succeed())
+				})
+			})
+		})
+
 		Context("Dest volume is handled properly", func() {
 			When("no destination volume is supplied", func() {
 				var destVolCap resource.Quantity
@@ -2012,6 +2395,20 @@ var _ = Describe("Rclone as a destination", func() {
 					validateJobEnvVars(job.Spec.Template.Spec.Containers[0].Env, false)
 				})
 			})
+			When("a version is to be restored", func() {
+				BeforeEach(func() {
+					rd.Spec.Rclone.RestoreVersion = ptr.To("20261019-120000")
+				})
+				It("should pass the version to the mover", func() {
+					j, e := mover.ensureJob(ctx, dPVC, sa, rcloneConfigSecret, nil)
+					Expect(e).NotTo(HaveOccurred())
+					Expect(j).To(BeNil()) // hasn't completed
+					job = &batchv1.Job{}
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: jobName, Namespace: ns.Name}, job)).To(Succeed())
+
+					validateEnvVar(job.Spec.Template.Spec.Containers[0].Env, "RESTORE_VERSION", "20261019-120000")
+				})
+			})
 		})
 
 		Context("Cleanup is handled properly", func() {
@@ -2173,6 +2570,7 @@ func validateJobEnvVars(env []corev1.EnvVar, isSource bool) {
 	}
 	validateEnvVar(env, "MOUNT_PATH", mountPath)
 	validateEnvVar(env, "RCLONE_CONFIG_SECTION", testRcloneConfigSection)
+	validateEnvVar(env, "RCLONE_MODE", string(volsyncv1alpha1.RcloneModeSync))
 }
 
 func validateEnvVar(env []corev1.EnvVar, envVarName, envVarExpectedValue string) {
@@ -144,10 +144,13 @@ func (rb *Builder) FromSource(client client.Client, logger logr.Logger,
 		pruneInterval:         source.Spec.Restic.PruneIntervalDays,
 		retainPolicy:          source.Spec.Restic.Retain,
 		unlock:                source.Spec.Restic.Unlock,
+		copyTo:                source.Spec.Restic.CopyTo,
+		maintenance:           source.Spec.Restic.Maintenance,
 		sourceStatus:          source.Status.Restic,
 		latestMoverStatus:     source.Status.LatestMoverStatus,
 		moverConfig:           source.Spec.Restic.MoverConfig,
 		moverVolumes:          source.Spec.Restic.MoverVolumes,
+		tuning:                source.Spec.Restic.Tuning,
 	}, nil
 }
 
@@ -159,6 +162,11 @@ func (rb *Builder) FromDestination(client client.Client, logger logr.Logger,
 		return nil, nil
 	}
 
+	// Create ReplicationDestinationResticStatus to write restic status
+	if destination.Status.Restic == nil {
+		destination.Status.Restic = &volsyncv1alpha1.ReplicationDestinationResticStatus{}
+	}
+
 	if destination.Status.LatestMoverStatus == nil {
 		destination.Status.LatestMoverStatus = &volsyncv1alpha1.MoverStatus{}
 	}
@@ -168,6 +176,7 @@ func (rb *Builder) FromDestination(client client.Client, logger logr.Logger,
 		volumehandler.WithRecorder(eventRecorder),
 		volumehandler.WithOwner(destination),
 		volumehandler.FromDestination(&destination.Spec.Restic.ReplicationDestinationVolumeOptions),
+		volumehandler.VolumeMode(destination.Spec.Restic.VolumeMode), // Allow setting block mode for dynamic dest PVC
 	)
 	if err != nil {
 		return nil, err
@@ -200,8 +209,11 @@ func (rb *Builder) FromDestination(client client.Client, logger logr.Logger,
 		restoreAsOf:                 destination.Spec.Restic.RestoreAsOf,
 		previous:                    destination.Spec.Restic.Previous,
 		enableFileDeletionOnRestore: destination.Spec.Restic.EnableFileDeletion,
+		dryRun:                      destination.Spec.Restic.DryRun,
+		destStatus:                  destination.Status.Restic,
 		latestMoverStatus:           destination.Status.LatestMoverStatus,
 		moverConfig:                 destination.Spec.Restic.MoverConfig,
 		moverVolumes:                destination.Spec.Restic.MoverVolumes,
+		tuning:                      destination.Spec.Restic.Tuning,
 	}, nil
 }
@@ -32,9 +32,13 @@ var resticRegex = regexp.MustCompile(
 		`^\s*([cC]reated)|` +
 		`^\s*([rR]epository)\s.+([oO]pened)|` +
 		`^\s*([rR]estoring)|` +
+		`^\s*(Restore preview)|` +
 		`^\s*([nN]o parent snapshot)|` +
 		`^\s*([uU]sing parent snapshot)|` +
 		`^\s*([aA]dded to the repository)|` +
+		`^\s*(=== Starting (copy|prune|check))|` +
+		`^\s*([nN]o errors were found)|` +
+		`^\s*([sS]kipping source snapshot)|` +
 		`^\s*([sS]uccessfully)|` +
 		`(RESTORE_OPTIONS)|` +
 		`([iI]nitialize [dD]ir)|` +
@@ -194,6 +194,130 @@ Restic completed in 4s`
 		})
 	})
 
+	Context("Restic source mover logs with copyTo", func() {
+		It("Should filter the logs from a successful replication source (restic backup and copy)", func() {
+			// Sample backup log for restic mover with a copy to a secondary repository
+			// nolint:lll
+			resticSourceLog := `Starting container
+VolSync restic container version: unknown
+backup copy
+restic 0.18.1 compiled with go1.24.6 on linux/amd64
+Testing mandatory env variables
+== Checking directory for content ===
+=== Check for dir initialized ===
+dir is initialized
+=== Starting backup ===
+/data /
+repository 3dd0878c opened (version 2, compression level auto)
+using parent snapshot eaf1a6ed
+
+Files:           0 new,     4 changed,     0 unmodified
+Dirs:            0 new,     0 changed,     0 unmodified
+Added to the repository: 1.653 KiB (562 B stored)
+
+processed 4 files, 1.494 KiB in 0:00
+snapshot 6b128c1e saved
+/
+=== Starting forget ===
+=== Starting copy ===
+=== Check for dir initialized ===
+dir is initialized
+repository 3dd0878c opened (version 2, compression level auto)
+repository 8f23f1a2 opened (version 2, compression level auto)
+[0:00] 100.00%  2 / 2 index files loaded
+
+snapshot 6b128c1e of [/data] at 2023-04-08 04:23:11.125 +0000 UTC by volsync@volsync
+  copy started, this may take a while...
+[0:00] 100.00%  3 / 3 packs copied
+snapshot 9d1c4e32 saved
+=== Starting forget ===
+Restic completed in 6s
+=== Done ===`
+
+			expectedFilteredResticSourceLog := `repository 3dd0878c opened (version 2, compression level auto)
+using parent snapshot eaf1a6ed
+Added to the repository: 1.653 KiB (562 B stored)
+processed 4 files, 1.494 KiB in 0:00
+snapshot 6b128c1e saved
+=== Starting copy ===
+repository 3dd0878c opened (version 2, compression level auto)
+repository 8f23f1a2 opened (version 2, compression level auto)
+snapshot 9d1c4e32 saved
+Restic completed in 6s`
+
+			reader := strings.NewReader(resticSourceLog)
+			filteredLines, err := utils.FilterLogs(reader, restic.LogLineFilterSuccess)
+			Expect(err).NotTo(HaveOccurred())
+
+			logger.Info("Logs after filter", "filteredLines", filteredLines)
+			Expect(filteredLines).To(Equal(expectedFilteredResticSourceLog))
+		})
+	})
+
+	Context("Restic maintenance logs", func() {
+		It("Should filter the logs from a successful maintenance job (restic prune and check)", func() {
+			// Sample log for a restic maintenance job
+			// nolint:lll
+			resticMaintenanceLog := `Starting container
+VolSync restic container version: unknown
+prune check
+restic 0.18.1 compiled with go1.24.6 on linux/amd64
+Testing mandatory env variables
+=== Starting prune ===
+repository 3dd0878c opened (version 2, compression level auto)
+loading indexes...
+loading all snapshots...
+finding data that is still in use for 3 snapshots
+[0:00] 100.00%  3 / 3 snapshots
+searching used packs...
+collecting packs for deletion and repacking
+[0:00] 100.00%  9 / 9 packs processed
+
+to repack:            0 blobs / 0 B
+this removes:         0 blobs / 0 B
+to delete:            2 blobs / 1.003 KiB
+total prune:          2 blobs / 1.003 KiB
+remaining:           31 blobs / 12.430 KiB
+unused size after prune: 0 B (0.00% of remaining size)
+
+rebuilding index
+[0:00] 100.00%  8 / 8 indexes processed
+deleting obsolete index files
+[0:00] 100.00%  2 / 2 files deleted
+removing 1 old packs
+[0:00] 100.00%  1 / 1 files deleted
+done
+=== Starting check ===
+using temporary cache in /tmp/restic-check-cache-1806235512
+repository 3dd0878c opened (version 2, compression level auto)
+created new cache in /tmp/restic-check-cache-1806235512
+create exclusive lock for repository
+load indexes
+[0:00] 100.00%  1 / 1 index files loaded
+check all packs
+check snapshots, trees and blobs
+[0:00] 100.00%  2 / 2 snapshots
+no errors were found
+Restic completed in 5s
+=== Done ===`
+
+			expectedFilteredResticMaintenanceLog := `=== Starting prune ===
+repository 3dd0878c opened (version 2, compression level auto)
+=== Starting check ===
+repository 3dd0878c opened (version 2, compression level auto)
+created new cache in /tmp/restic-check-cache-1806235512
+no errors were found
+Restic completed in 5s`
+
+			reader := strings.NewReader(resticMaintenanceLog)
+			filteredLines, err := utils.FilterLogs(reader, restic.LogLineFilterSuccess)
+			Expect(err).NotTo(HaveOccurred())
+
+			logger.Info("Logs after filter", "filteredLines", filteredLines)
+			Expect(filteredLines).To(Equal(expectedFilteredResticMaintenanceLog))
+		})
+	})
+
 	Context("Restic dest mover logs", func() {
 		// Sample restore log for restic mover
 		// nolint:lll
@@ -226,6 +350,36 @@ Restic completed in 9s`
 		})
 	})
 
+	Context("Restic dest mover logs - restore preview", func() {
+		// Sample restore preview log for restic mover
+		// nolint:lll
+		resticDestlogPreview := `Starting container
+VolSync restic container version: unknown
+restore
+restic 0.18.1 compiled with go1.24.6 on linux/amd64
+Testing mandatory env variables
+=== Starting restore ===
+Selected restic snapshot with id: 0ff74383
+=== Starting restore preview ===
+/data /
+/
+Restore preview of snapshot 0ff74383: 12 to add, 3 to change, 5 to delete
+Restic completed in 3s
+=== Done ===`
+
+		expectedFilteredResticDestlogPreview := `Restore preview of snapshot 0ff74383: 12 to add, 3 to change, 5 to delete
+Restic completed in 3s`
+
+		It("Should filter the logs from a replication dest restore preview", func() {
+			reader := strings.NewReader(resticDestlogPreview)
+			filteredLines, err := utils.FilterLogs(reader, restic.LogLineFilterSuccess)
+			Expect(err).NotTo(HaveOccurred())
+
+			logger.Info("Logs after filter", "filteredLines", filteredLines)
+			Expect(filteredLines).To(Equal(expectedFilteredResticDestlogPreview))
+		})
+	})
+
 	Context("Restic dest mover logs - empty repo/path (not initialized previously)", func() {
 		// Sample restore log for restic mover
 		// nolint:lll
@@ -0,0 +1,429 @@
+//go:build !disable_restic
+
+/*
+Copyright 2021 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package restic
+
+import (
+	"context"
+	"time"
+
+	cron "github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+const (
+	// Maintenance Jobs are labeled with a hash of the repository so that
+	// Jobs for the same repository can be found across namespaces
+	repositoryLabelKey = utils.VolsyncLabelPrefix + "/restic-repository"
+	// How long a backup that started before maintenance waits for the lock on
+	// the repository, rather than failing
+	retryLock = "1h"
+	// The Lease on a repository is renewed on each reconcile while its
+	// maintenance Job runs, and can be taken over by another owner once it
+	// has expired. Leases are kept in the operator namespace, where the
+	// leader election Role grants access to them.
+	maintenanceLeaseDuration = 10 * time.Minute
+)
+
+var _ mover.Maintainer = &Mover{}
+
+// Maintain runs restic prune (and optionally check) on the maintenance
+// schedule, independent of the backup schedule. The maintenance Job is
+// coordinated with all other ReplicationSources using the same repository so
+// that only one maintenance Job runs per repository at a time.
+func (m *Mover) Maintain(ctx context.Context) (mover.Result, error) {
+	if !m.isSource {
+		return mover.Complete(), nil
+	}
+	if m.maintenance == nil {
+		m.sourceStatus.NextMaintenance = nil
+		return mover.Complete(), nil
+	}
+
+	schedule, err := getMaintenanceSchedule(m.maintenance.Schedule)
+	if err != nil {
+		m.logger.Error(err, "error parsing maintenance schedule", "cronspec", m.maintenance.Schedule)
+		return mover.InProgress(), err
+	}
+
+	next := m.nextMaintenanceTime(schedule)
+	m.sourceStatus.NextMaintenance = &metav1.Time{Time: next}
+	if time.Now().Before(next) {
+		return mover.RetryAfter(time.Until(next)), nil
+	}
+
+	// Don't start maintenance while paused, un-pausing will trigger a reconcile
+	if m.paused {
+		return mover.Complete(), nil
+	}
+
+	sa, err := m.saHandler.Reconcile(ctx, m.logger)
+	if sa == nil || err != nil {
+		return mover.InProgress(), err
+	}
+
+	repo, err := m.validateRepository(ctx, m.repositoryName)
+	if repo == nil || err != nil {
+		return mover.InProgress(), err
+	}
+
+	var copyToRepo *corev1.Secret
+	if m.copyTo != nil {
+		copyToRepo, err = m.validateRepository(ctx, m.copyTo.Repository)
+		if copyToRepo == nil || err != nil {
+			return mover.InProgress(), err
+		}
+	}
+
+	customCAObj, err := utils.ValidateCustomCA(ctx, m.client, m.logger,
+		m.owner.GetNamespace(), m.customCASpec)
+	if err != nil {
+		return mover.InProgress(), err
+	}
+
+	job, err := m.ensureMaintenanceJob(ctx, sa, repo, copyToRepo, customCAObj)
+	if job == nil || err != nil {
+		return mover.InProgress(), err
+	}
+
+	now := metav1.Now()
+	m.sourceStatus.LastPruned = &now
+	if m.maintenance.Check {
+		m.sourceStatus.LastChecked = &now
+	}
+	next = schedule.Next(now.Time)
+	m.sourceStatus.NextMaintenance = &metav1.Time{Time: next}
+	m.logger.Info("maintenance completed", ".Status.Restic.NextMaintenance", next)
+
+	// Remove the Job, other ReplicationSources in the namespace that use the
+	// repository create their maintenance Job with the same name
+	if err := m.client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+		return mover.InProgress(), client.IgnoreNotFound(err)
+	}
+
+	return mover.RetryAfter(time.Until(next)), nil
+}
+
+func getMaintenanceSchedule(cronspec string) (cron.Schedule, error) {
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+	return parser.Parse(cronspec)
+}
+
+// nextMaintenanceTime determines when maintenance should next run based on
+// the last time the repository was pruned
+func (m *Mover) nextMaintenanceTime(schedule cron.Schedule) time.Time {
+	// If we've never pruned, the 1st one is scheduled from creation
+	lastMaintenance := m.owner.GetCreationTimestamp().Time
+	if !m.sourceStatus.LastPruned.IsZero() {
+		lastMaintenance = m.sourceStatus.LastPruned.Time
+	}
+	return schedule.Next(lastMaintenance)
+}
+
+func maintenanceJobName(repositoryHash string) string {
+	return mover.VolSyncPrefix + "restic-maintenance-" + repositoryHash
+}
+
+// shouldWaitForMaintenance returns true if the backup should not start until
+// maintenance of the repository has completed, as prune holds an exclusive
+// lock on the repository. A backup that has already started is left to wait
+// for the lock.
+func (m *Mover) shouldWaitForMaintenance(ctx context.Context, repo *corev1.Secret) (bool, error) {
+	if !m.isSource {
+		return false, nil
+	}
+	job := &batchv1.Job{}
+	err := m.client.Get(ctx, types.NamespacedName{
+		Name:      utils.GetJobName(mover.VolSyncPrefix+"src-", m.owner),
+		Namespace: m.owner.GetNamespace(),
+	}, job)
+	if err == nil || !kerrors.IsNotFound(err) {
+		return false, err
+	}
+	return m.maintenanceRunning(ctx, repo)
+}
+
+func maintenanceLeaseName(repositoryHash string) string {
+	return mover.VolSyncPrefix + "restic-" + repositoryHash
+}
+
+// leaseHolderIdentity identifies the owner in the Leases it holds
+func (m *Mover) leaseHolderIdentity() string {
+	return m.owner.GetNamespace() + "/" + m.owner.GetName()
+}
+
+func leaseExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	duration := time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	return now.After(lease.Spec.RenewTime.Add(duration))
+}
+
+// maintenanceRunning returns true if the maintenance Lease for the repository
+// is held, by any owner
+func (m *Mover) maintenanceRunning(ctx context.Context, repo *corev1.Secret) (bool, error) {
+	repositoryHash := utils.GetHashedName(string(repo.Data["RESTIC_REPOSITORY"]))
+	lease := &coordinationv1.Lease{}
+	err := m.client.Get(ctx, types.NamespacedName{
+		Name:      maintenanceLeaseName(repositoryHash),
+		Namespace: utils.GetVolSyncNamespace(),
+	}, lease)
+	if err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if leaseExpired(lease, time.Now()) {
+		return false, nil
+	}
+	m.logger.Info("waiting for maintenance of the repository to complete",
+		"holder", ptr.Deref(lease.Spec.HolderIdentity, ""))
+	return true, nil
+}
+
+// acquireMaintenanceLease takes or renews the Lease on the repository, so that
+// only one owner runs maintenance on it at a time, across namespaces. It
+// returns false while another owner holds the Lease.
+func (m *Mover) acquireMaintenanceLease(ctx context.Context, repositoryHash string) (bool, error) {
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      maintenanceLeaseName(repositoryHash),
+			Namespace: utils.GetVolSyncNamespace(),
+		},
+	}
+	logger := m.logger.WithValues("lease", client.ObjectKeyFromObject(lease))
+	holder := m.leaseHolderIdentity()
+	now := metav1.NowMicro()
+
+	err := m.client.Get(ctx, client.ObjectKeyFromObject(lease), lease)
+	if kerrors.IsNotFound(err) {
+		utils.SetOwnedByVolSync(lease)
+		utils.AddLabel(lease, repositoryLabelKey, repositoryHash)
+		lease.Spec = coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder,
+			LeaseDurationSeconds: ptr.To(int32(maintenanceLeaseDuration.Seconds())),
+			AcquireTime:          &now,
+			RenewTime:            &now,
+		}
+		if err := m.client.Create(ctx, lease); err != nil {
+			if kerrors.IsAlreadyExists(err) {
+				logger.Info("another owner acquired the maintenance lease first")
+				return false, nil
+			}
+			logger.Error(err, "unable to create maintenance lease")
+			return false, err
+		}
+		return true, nil
+	}
+	if err != nil {
+		logger.Error(err, "unable to get maintenance lease")
+		return false, err
+	}
+
+	if ptr.Deref(lease.Spec.HolderIdentity, "") != holder {
+		if !leaseExpired(lease, now.Time) {
+			logger.Info("waiting for maintenance of the repository by another owner to complete",
+				"holder", ptr.Deref(lease.Spec.HolderIdentity, ""))
+			return false, nil
+		}
+		logger.Info("taking over expired maintenance lease", "holder", ptr.Deref(lease.Spec.HolderIdentity, ""))
+		lease.Spec.HolderIdentity = &holder
+		lease.Spec.AcquireTime = &now
+	}
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(maintenanceLeaseDuration.Seconds()))
+	lease.Spec.RenewTime = &now
+	if err := m.client.Update(ctx, lease); err != nil {
+		if kerrors.IsConflict(err) || kerrors.IsNotFound(err) {
+			// Changed by another owner since it was read, try again later
+			return false, nil
+		}
+		logger.Error(err, "unable to renew maintenance lease")
+		return false, err
+	}
+	return true, nil
+}
+
+// releaseMaintenanceLease lets other owners run maintenance on the repository
+func (m *Mover) releaseMaintenanceLease(ctx context.Context, repositoryHash string) error {
+	lease := &coordinationv1.Lease{}
+	err := m.client.Get(ctx, types.NamespacedName{
+		Name:      maintenanceLeaseName(repositoryHash),
+		Namespace: utils.GetVolSyncNamespace(),
+	}, lease)
+	if err != nil || ptr.Deref(lease.Spec.HolderIdentity, "") != m.leaseHolderIdentity() {
+		return client.IgnoreNotFound(err)
+	}
+	// Only delete the Lease as it was read, in case another owner has taken it
+	// over since
+	err = m.client.Delete(ctx, lease, client.Preconditions{ResourceVersion: &lease.ResourceVersion})
+	if kerrors.IsConflict(err) {
+		return nil
+	}
+	return client.IgnoreNotFound(err)
+}
+
+//nolint:funlen
+func (m *Mover) ensureMaintenanceJob(ctx context.Context, sa *corev1.ServiceAccount, repo *corev1.Secret,
+	copyToRepo *corev1.Secret, customCAObj utils.CustomCAObject) (*batchv1.Job, error) {
+	repositoryHash := utils.GetHashedName(string(repo.Data["RESTIC_REPOSITORY"]))
+
+	// Only one owner may run maintenance on a repository at a time
+	acquired, err := m.acquireMaintenanceLease(ctx, repositoryHash)
+	if !acquired || err != nil {
+		return nil, err
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      maintenanceJobName(repositoryHash),
+			Namespace: m.owner.GetNamespace(),
+		},
+	}
+	logger := m.logger.WithValues("job", client.ObjectKeyFromObject(job))
+
+	_, err = utils.CreateOrUpdateDeleteOnImmutableErr(ctx, m.client, job, logger, func() error {
+		if err := ctrl.SetControllerReference(m.owner, job, m.client.Scheme()); err != nil {
+			logger.Error(err, utils.ErrUnableToSetControllerRef)
+			return err
+		}
+		// Not marked for cleanup, the maintenance Job must be able to outlive a
+		// synchronization
+		utils.SetOwnedByVolSync(job)
+		utils.AddLabel(job, repositoryLabelKey, repositoryHash)
+		job.Spec.Template.Name = job.Name
+		utils.SetOwnedByVolSync(&job.Spec.Template)
+		backoffLimit := int32(8)
+		job.Spec.BackoffLimit = &backoffLimit
+		job.Spec.Parallelism = ptr.To(int32(1))
+
+		actions := []string{"prune"}
+		if m.maintenance.Check {
+			actions = append(actions, "check")
+		}
+		logger.Info("job actions", "actions", actions)
+
+		podSpec := &job.Spec.Template.Spec
+		envVars := []corev1.EnvVar{
+			{Name: "DATA_DIR", Value: mountPath},
+			{Name: "RESTIC_CACHE_DIR", Value: resticCacheMountPath},
+			// Maintenance does not access the data volume
+			{Name: "PRIVILEGED_MOVER", Value: "0"},
+		}
+		envVars = append(envVars, m.repositoryEnvVars(repo, copyToRepo)...)
+
+		podSpec.Containers = []corev1.Container{{
+			Name:    "restic",
+			Env:     envVars,
+			Command: []string{"/mover-restic/entry.sh"},
+			Args:    actions,
+			Image:   m.containerImage,
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: ptr.To(false),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{"ALL"},
+				},
+				Privileged:             ptr.To(false),
+				ReadOnlyRootFilesystem: ptr.To(true),
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: resticCache, MountPath: resticCacheMountPath},
+				{Name: "tempdir", MountPath: "/tmp"},
+			},
+		}}
+		podSpec.RestartPolicy = corev1.RestartPolicyNever
+		podSpec.ServiceAccountName = sa.Name
+		podSpec.Volumes = []corev1.Volume{
+			// The cache PVC may be in use by a backup, so maintenance uses a
+			// scratch cache instead
+			{Name: resticCache, VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			},
+			{Name: "tempdir", VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium: corev1.StorageMediumMemory,
+				}},
+			},
+		}
+		addCustomCA(podSpec, customCAObj)
+		addGCSCredentials(podSpec, repo, "")
+		if copyToRepo != nil {
+			addGCSCredentials(podSpec, copyToRepo, copyToEnvPrefix)
+		}
+
+		// Update the job securityContext, podLabels and resourceRequirements from moverConfig (if specified)
+		utils.UpdatePodTemplateSpecFromMoverConfig(&job.Spec.Template, m.moverConfig, corev1.ResourceRequirements{})
+		return nil
+	})
+	if kerrors.IsAlreadyExists(err) {
+		// Another owner created the maintenance Job first
+		logger.Info("maintenance job for the repository already exists")
+		return nil, nil
+	}
+	// If Job had failed, delete it so it can be recreated
+	if job.Status.Failed >= *job.Spec.BackoffLimit {
+		// Update status with mover logs from failed job
+		utils.UpdateMoverStatusForFailedJob(ctx, m.logger, m.maintenanceStatus(), job.GetName(),
+			job.GetNamespace(), utils.AllLines)
+
+		logger.Info("deleting job -- backoff limit reached")
+		err = m.client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil {
+			return nil, err
+		}
+		return nil, m.releaseMaintenanceLease(ctx, repositoryHash)
+	}
+	if err != nil {
+		logger.Error(err, "reconcile failed")
+		return nil, err
+	}
+
+	// Stop here if the job hasn't completed yet
+	if job.Status.Succeeded == 0 {
+		return nil, nil
+	}
+
+	logger.Info("job completed")
+
+	// update status with mover logs from successful job
+	utils.UpdateMoverStatusForSuccessfulJob(ctx, m.logger, m.maintenanceStatus(), job.GetName(),
+		job.GetNamespace(), LogLineFilterSuccess)
+
+	if err := m.releaseMaintenanceLease(ctx, repositoryHash); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (m *Mover) maintenanceStatus() *volsyncv1alpha1.MoverStatus {
+	if m.sourceStatus.LatestMaintenanceStatus == nil {
+		m.sourceStatus.LatestMaintenanceStatus = &volsyncv1alpha1.MoverStatus{}
+	}
+	return m.sourceStatus.LatestMaintenanceStatus
+}
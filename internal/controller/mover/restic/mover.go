@@ -21,10 +21,14 @@ package restic
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"path"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -49,12 +53,16 @@ import (
 const (
 	resticCacheMountPath = "/cache"
 	mountPath            = "/data"
+	devicePath           = "/dev/block"
 	dataVolumeName       = "data"
 	resticCache          = "cache"
 	resticCAMountPath    = "/customCA"
 	resticCAFilename     = "ca.crt"
 	credentialDir        = "/credentials"
+	copyToCredentialDir  = "/copy-to-credentials"
 	gcsCredentialFile    = "gcs.json"
+	// Env vars for the copyTo repository are prefixed with this
+	copyToEnvPrefix = "COPY_TO_"
 )
 
 // Mover is the reconciliation logic for the Restic-based data mover.
@@ -78,15 +86,20 @@ type Mover struct {
 	latestMoverStatus     *volsyncv1alpha1.MoverStatus
 	moverConfig           volsyncv1alpha1.MoverConfig
 	moverVolumes          []volsyncv1alpha1.MoverVolume
+	tuning                *volsyncv1alpha1.ResticTuningSpec
 	// Source-only fields
 	pruneInterval *int32
 	unlock        string
 	retainPolicy  *volsyncv1alpha1.ResticRetainPolicy
+	copyTo        *volsyncv1alpha1.ResticCopyToSpec
+	maintenance   *volsyncv1alpha1.ResticMaintenanceSpec
 	sourceStatus  *volsyncv1alpha1.ReplicationSourceResticStatus
 	// Destination-only fields
 	previous                    *int32
 	restoreAsOf                 *string
 	enableFileDeletionOnRestore bool
+	dryRun                      bool
+	destStatus                  *volsyncv1alpha1.ReplicationDestinationResticStatus
 	cleanupTempPVC              bool
 	cleanupCachePVC             bool
 }
@@ -116,6 +129,10 @@ func (m *Mover) Synchronize(ctx context.Context) (mover.Result, error) {
 		return mover.InProgress(), err
 	}
 
+	if err := m.validateDryRun(dataPVC); err != nil {
+		return mover.InProgress(), err
+	}
+
 	// Allocate cache volume
 	// cleanupCachePVC will always be false for replicationsources - it's only set in the builder FromDestination()
 	cachePVC, err := m.ensureCache(ctx, dataPVC, m.cleanupCachePVC)
@@ -130,11 +147,20 @@ func (m *Mover) Synchronize(ctx context.Context) (mover.Result, error) {
 	}
 
 	// Validate Repository Secret
-	repo, err := m.validateRepository(ctx)
+	repo, err := m.validateRepository(ctx, m.repositoryName)
 	if repo == nil || err != nil {
 		return mover.InProgress(), err
 	}
 
+	// Validate the copyTo Repository Secret if in spec
+	var copyToRepo *corev1.Secret
+	if m.copyTo != nil {
+		copyToRepo, err = m.validateRepository(ctx, m.copyTo.Repository)
+		if copyToRepo == nil || err != nil {
+			return mover.InProgress(), err
+		}
+	}
+
 	// Validate custom CA if in spec
 	customCAObj, err := utils.ValidateCustomCA(ctx, m.client, m.logger,
 		m.owner.GetNamespace(), m.customCASpec)
@@ -149,12 +175,23 @@ func (m *Mover) Synchronize(ctx context.Context) (mover.Result, error) {
 		return mover.InProgress(), err
 	}
 
+	// Don't start a backup while maintenance holds the lock on the repository
+	wait, err := m.shouldWaitForMaintenance(ctx, repo)
+	if wait || err != nil {
+		return mover.InProgress(), err
+	}
+
 	// Start mover Job
-	job, err := m.ensureJob(ctx, cachePVC, dataPVC, sa, repo, customCAObj)
+	job, err := m.ensureJob(ctx, cachePVC, dataPVC, sa, repo, copyToRepo, customCAObj)
 	if job == nil || err != nil {
 		return mover.InProgress(), err
 	}
 
+	// A restore preview doesn't change the data, so there's no new image
+	if !m.isSource && m.dryRun {
+		return mover.Complete(), nil
+	}
+
 	// On the destination, preserve the image and return it
 	if !m.isSource {
 		image, err := m.vh.EnsureImage(ctx, m.logger, dataPVC)
@@ -196,6 +233,9 @@ func (m *Mover) ensureCache(ctx context.Context,
 		volumehandler.From(m.vh),
 	}
 
+	// The cache is always a filesystem, even if the data volume is a block device
+	cacheConfig = append(cacheConfig, volumehandler.VolumeMode(ptr.To(corev1.PersistentVolumeFilesystem)))
+
 	// Cache capacity defaults to 1Gi but can be overridden
 	cacheCapacity := resource.MustParse("1Gi")
 	if m.cacheCapacity != nil {
@@ -310,10 +350,10 @@ func (m *Mover) getDestinationPVCName() (bool, string) {
 	return true, *m.mainPVCName
 }
 
-func (m *Mover) validateRepository(ctx context.Context) (*corev1.Secret, error) {
+func (m *Mover) validateRepository(ctx context.Context, repositoryName string) (*corev1.Secret, error) {
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      m.repositoryName,
+			Name:      repositoryName,
 			Namespace: m.owner.GetNamespace(),
 		},
 	}
@@ -329,7 +369,7 @@ func (m *Mover) validateRepository(ctx context.Context) (*corev1.Secret, error)
 //nolint:funlen
 func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolumeClaim,
 	dataPVC *corev1.PersistentVolumeClaim, sa *corev1.ServiceAccount, repo *corev1.Secret,
-	customCAObj utils.CustomCAObject) (*batchv1.Job, error) {
+	copyToRepo *corev1.Secret, customCAObj utils.CustomCAObject) (*batchv1.Job, error) {
 	dir := "src"
 	if !m.isSource {
 		dir = "dst"
@@ -366,6 +406,7 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 		var restoreOptions = ""
 
 		readOnlyVolume := false
+		blockVolume := utils.PvcIsBlockMode(dataPVC)
 		var actions []string
 		if m.isSource {
 			actions = []string{"backup"}
@@ -375,6 +416,11 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 				actions = []string{"unlock", "backup"}
 			}
 
+			if copyToRepo != nil {
+				// Copy the new snapshot to the secondary repository
+				actions = append(actions, "copy")
+			}
+
 			if m.shouldPrune(time.Now()) {
 				actions = append(actions, "prune")
 			}
@@ -396,6 +442,11 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 			if m.enableFileDeletionOnRestore {
 				restoreOptions = "--delete"
 			}
+
+			// A restore preview must not modify the data
+			if m.dryRun {
+				readOnlyVolume = true
+			}
 		}
 		logger.Info("job actions", "actions", actions)
 		podSpec := &job.Spec.Template.Spec
@@ -407,27 +458,12 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 			{Name: "RESTORE_AS_OF", Value: restoreAsOf},
 			{Name: "SELECT_PREVIOUS", Value: previous},
 			{Name: "RESTORE_OPTIONS", Value: restoreOptions},
-			// We populate environment variables from the restic repo
-			// Secret. They are taken 1-for-1 from the Secret into env vars.
-			// The allowed variables are defined by restic.
-			// https://restic.readthedocs.io/en/stable/040_backup.html#environment-variables
-			// Mandatory variables are needed to define the repository
-			// location and its password.
-			utils.EnvFromSecret(repo.Name, "RESTIC_REPOSITORY", false),
-			utils.EnvFromSecret(repo.Name, "RESTIC_PASSWORD", false),
+			{Name: "BACKUP_OPTIONS", Value: generateBackupOptions(m.tuning)},
 		}
-
-		// Append optional restic env vars from the secret
-		envVars = appendResticOptionalEnvVars(repo, envVars)
-
-		// Rclone env vars for restic if they are in the secret
-		envVars = utils.AppendRCloneEnvVars(repo, envVars)
-
-		// Cluster-wide proxy settings
-		envVars = utils.AppendEnvVarsForClusterWideProxy(envVars)
-
-		// Run mover in debug mode if required
-		envVars = utils.AppendDebugMoverEnvVar(m.owner, envVars)
+		if m.dryRun {
+			envVars = append(envVars, corev1.EnvVar{Name: "RESTORE_PREVIEW", Value: "1"})
+		}
+		envVars = append(envVars, m.repositoryEnvVars(repo, copyToRepo)...)
 
 		podSpec.Containers = []corev1.Container{{
 			Name:    "restic",
@@ -443,12 +479,20 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 				Privileged:             ptr.To(false),
 				ReadOnlyRootFilesystem: ptr.To(true),
 			},
-			VolumeMounts: []corev1.VolumeMount{
-				{Name: dataVolumeName, MountPath: mountPath},
-				{Name: resticCache, MountPath: resticCacheMountPath},
-				{Name: "tempdir", MountPath: "/tmp"},
-			},
 		}}
+		volumeMounts := []corev1.VolumeMount{}
+		if !blockVolume {
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: dataVolumeName, MountPath: mountPath})
+		}
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: resticCache, MountPath: resticCacheMountPath},
+			corev1.VolumeMount{Name: "tempdir", MountPath: "/tmp"})
+		podSpec.Containers[0].VolumeMounts = volumeMounts
+		if blockVolume {
+			// Block volumes are backed up/restored as a single file in the repository
+			podSpec.Containers[0].VolumeDevices = []corev1.VolumeDevice{
+				{Name: dataVolumeName, DevicePath: devicePath},
+			}
+		}
 		podSpec.RestartPolicy = corev1.RestartPolicyNever
 		podSpec.ServiceAccountName = sa.Name
 		podSpec.Volumes = []corev1.Volume{
@@ -478,53 +522,10 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 			podSpec.NodeSelector = affinity.NodeSelector
 			podSpec.Tolerations = affinity.Tolerations
 		}
-		if customCAObj != nil {
-			// Tell mover where to find the cert
-			podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, corev1.EnvVar{
-				Name:  "CUSTOM_CA",
-				Value: path.Join(resticCAMountPath, resticCAFilename),
-			})
-			// Mount the custom CA certificate
-			podSpec.Containers[0].VolumeMounts =
-				append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
-					Name:      "custom-ca",
-					MountPath: resticCAMountPath,
-				})
-			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
-				Name:         "custom-ca",
-				VolumeSource: customCAObj.GetVolumeSource(resticCAFilename),
-			})
-		}
-		// We handle GOOGLE_APPLICATION_CREDENTIALS specially...
-		// restic expects it to be an env var pointing to a file w/ the
-		// credentials, but we have users provide the actual file data in the
-		// Secret under that key name. The following code sets the env var to be
-		// what restic expects, then mounts just that Secret key into the
-		// container, pointed to by the env var.
-		if _, ok := repo.Data["GOOGLE_APPLICATION_CREDENTIALS"]; ok {
-			container := &podSpec.Containers[0]
-			// Tell restic where to look for the credential file
-			container.Env = append(container.Env, corev1.EnvVar{
-				Name:  "GOOGLE_APPLICATION_CREDENTIALS",
-				Value: path.Join(credentialDir, gcsCredentialFile),
-			})
-			// Mount the credential file
-			container.VolumeMounts =
-				append(container.VolumeMounts, corev1.VolumeMount{
-					Name:      "gcs-credentials",
-					MountPath: credentialDir,
-				})
-			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
-				Name: "gcs-credentials",
-				VolumeSource: corev1.VolumeSource{
-					Secret: &corev1.SecretVolumeSource{
-						SecretName: repo.Name,
-						Items: []corev1.KeyToPath{
-							{Key: "GOOGLE_APPLICATION_CREDENTIALS", Path: gcsCredentialFile},
-						},
-					},
-				},
-			})
+		addCustomCA(podSpec, customCAObj)
+		addGCSCredentials(podSpec, repo, "")
+		if copyToRepo != nil {
+			addGCSCredentials(podSpec, copyToRepo, copyToEnvPrefix)
 		}
 
 		// Update the job securityContext, podLabels and resourceRequirements from moverConfig (if specified)
@@ -560,6 +561,12 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 		// Update status with mover logs from failed job
 		utils.UpdateMoverStatusForFailedJob(ctx, m.logger, m.latestMoverStatus, job.GetName(), job.GetNamespace(),
 			utils.AllLines)
+		if m.isSource && copyToRepo != nil {
+			// Record the copy that failed, if the backup got that far
+			if err := m.updateCopyToStatus(ctx, job, copyToRepo, true); err != nil {
+				logger.Error(err, "unable to get copy result")
+			}
+		}
 
 		logger.Info("deleting job -- backoff limit reached")
 		err = m.client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
@@ -592,6 +599,21 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 			m.sourceStatus.LastPruned = &now
 			logger.Info("prune completed", ".Status.Restic.LastPruned", m.sourceStatus.LastPruned)
 		}
+
+		if copyToRepo != nil {
+			if err := m.updateCopyToStatus(ctx, job, copyToRepo, false); err != nil {
+				logger.Error(err, "unable to get copy result")
+				return nil, err
+			}
+		} else {
+			// Unset copyTo in status if copyTo is no longer set in the spec
+			m.sourceStatus.CopyTo = nil
+		}
+	} else if m.dryRun {
+		if err := m.updatePreviewStatus(ctx, job); err != nil {
+			logger.Error(err, "unable to get restore preview")
+			return nil, err
+		}
 	}
 
 	// update status with mover logs from successful job
@@ -602,7 +624,243 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 	return job, nil
 }
 
+// repositoryEnvVars returns the env vars that the mover needs to access the
+// repository and, if copyTo is used, the secondary repository
+func (m *Mover) repositoryEnvVars(repo *corev1.Secret, copyToRepo *corev1.Secret) []corev1.EnvVar {
+	envVars := []corev1.EnvVar{
+		// We populate environment variables from the restic repo
+		// Secret. They are taken 1-for-1 from the Secret into env vars.
+		// The allowed variables are defined by restic.
+		// https://restic.readthedocs.io/en/stable/040_backup.html#environment-variables
+		// Mandatory variables are needed to define the repository
+		// location and its password.
+		utils.EnvFromSecret(repo.Name, "RESTIC_REPOSITORY", false),
+		utils.EnvFromSecret(repo.Name, "RESTIC_PASSWORD", false),
+		// Options from the tuning spec are passed on the command line so they
+		// take precedence over any equivalent env vars from the secret
+		{Name: "GLOBAL_OPTIONS", Value: generateGlobalOptions(m.tuning)},
+		{Name: "RETRY_LOCK", Value: retryLock},
+	}
+
+	// Append optional restic env vars from the secret
+	envVars = appendResticOptionalEnvVars(repo, envVars)
+
+	// Rclone env vars for restic if they are in the secret
+	envVars = utils.AppendRCloneEnvVars(repo, envVars)
+
+	// Env vars for the secondary repository if copyTo is used
+	if copyToRepo != nil {
+		copyToRetainPolicy := m.retainPolicy
+		if m.copyTo.Retain != nil {
+			copyToRetainPolicy = m.copyTo.Retain
+		}
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  copyToEnvPrefix + "FORGET_OPTIONS",
+			Value: generateForgetOptions(copyToRetainPolicy),
+		}, corev1.EnvVar{
+			// The mover unsets these before using the secondary repository on
+			// its own, and passes them to restic copy with the RESTIC_FROM_
+			// prefix
+			Name:  "REPOSITORY_ENV_VARS",
+			Value: strings.Join(credentialEnvVarNames(repo), " "),
+		})
+		envVars = appendCopyToEnvVars(copyToRepo, envVars)
+	}
+
+	// Cluster-wide proxy settings
+	envVars = utils.AppendEnvVarsForClusterWideProxy(envVars)
+
+	// Run mover in debug mode if required
+	envVars = utils.AppendDebugMoverEnvVar(m.owner, envVars)
+
+	return envVars
+}
+
+// addCustomCA mounts the custom CA certificate (if any) into the mover
+// container
+func addCustomCA(podSpec *corev1.PodSpec, customCAObj utils.CustomCAObject) {
+	if customCAObj == nil {
+		return
+	}
+	// Tell mover where to find the cert
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, corev1.EnvVar{
+		Name:  "CUSTOM_CA",
+		Value: path.Join(resticCAMountPath, resticCAFilename),
+	})
+	// Mount the custom CA certificate
+	podSpec.Containers[0].VolumeMounts =
+		append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "custom-ca",
+			MountPath: resticCAMountPath,
+		})
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name:         "custom-ca",
+		VolumeSource: customCAObj.GetVolumeSource(resticCAFilename),
+	})
+}
+
+// addGCSCredentials handles GOOGLE_APPLICATION_CREDENTIALS specially...
+// restic expects it to be an env var pointing to a file w/ the
+// credentials, but we have users provide the actual file data in the
+// Secret under that key name. The following code sets the env var to be
+// what restic expects, then mounts just that Secret key into the
+// container, pointed to by the env var.
+// The credentials of the copyTo repository are mounted separately, with the
+// env var prefixed by copyToEnvPrefix.
+func addGCSCredentials(podSpec *corev1.PodSpec, repo *corev1.Secret, envPrefix string) {
+	if _, ok := repo.Data["GOOGLE_APPLICATION_CREDENTIALS"]; !ok {
+		return
+	}
+	volumeName := "gcs-credentials"
+	dir := credentialDir
+	if envPrefix == copyToEnvPrefix {
+		volumeName = "copy-to-gcs-credentials"
+		dir = copyToCredentialDir
+	}
+	container := &podSpec.Containers[0]
+	// Tell restic where to look for the credential file
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  envPrefix + "GOOGLE_APPLICATION_CREDENTIALS",
+		Value: path.Join(dir, gcsCredentialFile),
+	})
+	// Mount the credential file
+	container.VolumeMounts =
+		append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: dir,
+		})
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: repo.Name,
+				Items: []corev1.KeyToPath{
+					{Key: "GOOGLE_APPLICATION_CREDENTIALS", Path: gcsCredentialFile},
+				},
+			},
+		},
+	})
+}
+
+// terminationMessage returns the termination message of the container of the
+// newest mover pod of the Job, and whether a pod was found
+func (m *Mover) terminationMessage(ctx context.Context, job *batchv1.Job, jobFailed bool) (string, bool, error) {
+	pod, err := utils.GetNewestPodForJob(ctx, m.logger, job.GetName(), job.GetNamespace(), jobFailed)
+	if err != nil || pod == nil {
+		return "", false, err
+	}
+	var message string
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Terminated != nil {
+			message = cs.State.Terminated.Message
+		}
+	}
+	return message, true, nil
+}
+
+// updateCopyToStatus records the result of the copy into the secondary
+// repository in the status. The mover writes the result to the termination
+// message of its container.
+func (m *Mover) updateCopyToStatus(ctx context.Context, job *batchv1.Job, copyToRepo *corev1.Secret,
+	jobFailed bool) error {
+	message, found, err := m.terminationMessage(ctx, job, jobFailed)
+	if err != nil {
+		return err
+	}
+	result, err := parseCopyResult(message)
+	if err != nil {
+		return err
+	}
+	if !found || result.Result == "" {
+		if jobFailed {
+			// The Job failed before the copy
+			return nil
+		}
+		// The mover doesn't report the result if it can't be found
+		result.Result = volsyncv1alpha1.MoverResultSuccessful
+	}
+
+	status := &volsyncv1alpha1.ReplicationSourceResticCopyToStatus{
+		Repository: copyToRepo.GetName(),
+		Snapshot:   result.Snapshot,
+		Result:     result.Result,
+	}
+	if m.sourceStatus.CopyTo != nil && m.sourceStatus.CopyTo.Repository == copyToRepo.GetName() {
+		status.LastCopied = m.sourceStatus.CopyTo.LastCopied
+	}
+	if status.Result == volsyncv1alpha1.MoverResultSuccessful {
+		now := metav1.Now()
+		status.LastCopied = &now
+	}
+	m.sourceStatus.CopyTo = status
+	m.logger.Info("copy finished", ".Status.Restic.CopyTo", status)
+	return nil
+}
+
+// parseCopyResult parses the result of the copy written by the mover
+func parseCopyResult(message string) (*volsyncv1alpha1.ReplicationSourceResticCopyToStatus, error) {
+	result := &volsyncv1alpha1.ReplicationSourceResticCopyToStatus{}
+	if strings.TrimSpace(message) == "" {
+		return result, nil
+	}
+	if err := json.Unmarshal([]byte(message), result); err != nil {
+		return nil, fmt.Errorf("unable to parse copy result: %w", err)
+	}
+	return result, nil
+}
+
+// updatePreviewStatus records the result of a restore preview in the status.
+// The mover writes the result to the termination message of its container.
+func (m *Mover) updatePreviewStatus(ctx context.Context, job *batchv1.Job) error {
+	message, found, err := m.terminationMessage(ctx, job, false)
+	if err != nil {
+		return err
+	}
+	if !found {
+		m.logger.Info("No mover pods found to get restore preview from")
+		return nil
+	}
+	preview, err := parseRestorePreview(message)
+	if err != nil {
+		return err
+	}
+	now := metav1.Now()
+	preview.LastPreviewed = &now
+	m.destStatus.Preview = preview
+	m.logger.Info("restore preview completed", ".Status.Restic.Preview", preview)
+	return nil
+}
+
+// validateDryRun checks that a restore preview can be made of the data PVC. The
+// preview compares the files of the snapshot with those in the PVC, so it can't
+// be made of a block volume.
+func (m *Mover) validateDryRun(dataPVC *corev1.PersistentVolumeClaim) error {
+	if m.isSource || !m.dryRun || !utils.PvcIsBlockMode(dataPVC) {
+		return nil
+	}
+	err := errors.New("restic dryRun is not supported for block volumes")
+	m.logger.Error(err, "Restic Spec validation error")
+	return err
+}
+
+// parseRestorePreview parses the restore preview written by the mover. An
+// empty message means that there was no snapshot to restore.
+func parseRestorePreview(message string) (*volsyncv1alpha1.ReplicationDestinationResticPreviewStatus, error) {
+	preview := &volsyncv1alpha1.ReplicationDestinationResticPreviewStatus{}
+	if strings.TrimSpace(message) == "" {
+		return preview, nil
+	}
+	if err := json.Unmarshal([]byte(message), preview); err != nil {
+		return nil, fmt.Errorf("unable to parse restore preview: %w", err)
+	}
+	return preview, nil
+}
+
 func (m *Mover) shouldPrune(current time.Time) bool {
+	if m.maintenance != nil {
+		// Pruning is done on the maintenance schedule instead
+		return false
+	}
 	delta := time.Hour * 24 * 7 // default prune every 7 days
 	if m.pruneInterval != nil {
 		delta = time.Hour * 24 * time.Duration(*m.pruneInterval)
@@ -658,6 +916,45 @@ func generateForgetOptions(policy *volsyncv1alpha1.ResticRetainPolicy) string {
 	return forget
 }
 
+// generateGlobalOptions returns the restic options from the tuning spec that
+// apply to all restic commands
+func generateGlobalOptions(tuning *volsyncv1alpha1.ResticTuningSpec) string {
+	if tuning == nil {
+		return ""
+	}
+
+	var options []string
+	if tuning.Compression != nil {
+		options = append(options, "--compression", *tuning.Compression)
+	}
+	optionTable := []struct {
+		opt   string
+		value *int32
+	}{
+		{"--pack-size", tuning.PackSizeMiB},
+		{"--limit-upload", tuning.LimitUploadKiB},
+		{"--limit-download", tuning.LimitDownloadKiB},
+	}
+	for _, v := range optionTable {
+		if v.value != nil {
+			options = append(options, v.opt, strconv.Itoa(int(*v.value)))
+		}
+	}
+	if tuning.S3Connections != nil {
+		options = append(options, "-o", fmt.Sprintf("s3.connections=%d", *tuning.S3Connections))
+	}
+	return strings.Join(options, " ")
+}
+
+// generateBackupOptions returns the restic options from the tuning spec that
+// only apply to restic backup
+func generateBackupOptions(tuning *volsyncv1alpha1.ResticTuningSpec) string {
+	if tuning == nil || tuning.ReadConcurrency == nil {
+		return ""
+	}
+	return fmt.Sprintf("--read-concurrency %d", *tuning.ReadConcurrency)
+}
+
 var resticOptionalEnvVars = [...]string{
 	"RESTIC_COMPRESSION", // New in v0.14.0
 	"RESTIC_PACK_SIZE",   // New in v0.14.0
@@ -722,3 +1019,36 @@ func appendResticOptionalEnvVars(secret *corev1.Secret, envVars []corev1.EnvVar)
 
 	return envVars
 }
+
+// appendCopyToEnvVars adds the env vars from the copyTo repository secret.
+// These are prefixed with copyToEnvPrefix so they do not collide with the env
+// vars of the primary repository. The mover swaps them in when running the copy.
+func appendCopyToEnvVars(secret *corev1.Secret, envVars []corev1.EnvVar) []corev1.EnvVar {
+	var copyToEnvVars []corev1.EnvVar
+	copyToEnvVars = append(copyToEnvVars,
+		utils.EnvFromSecret(secret.Name, "RESTIC_REPOSITORY", false),
+		utils.EnvFromSecret(secret.Name, "RESTIC_PASSWORD", false))
+	copyToEnvVars = appendResticOptionalEnvVars(secret, copyToEnvVars)
+	copyToEnvVars = utils.AppendRCloneEnvVars(secret, copyToEnvVars)
+
+	for _, envVar := range copyToEnvVars {
+		envVar.Name = copyToEnvPrefix + envVar.Name
+		envVars = append(envVars, envVar)
+	}
+
+	return envVars
+}
+
+// credentialEnvVarNames returns the names of the optional env vars that the
+// mover takes from a repository Secret, sorted
+func credentialEnvVarNames(secret *corev1.Secret) []string {
+	names := []string{}
+	for key := range secret.Data {
+		if slices.Contains(resticOptionalEnvVars[:], key) || key == "GOOGLE_APPLICATION_CREDENTIALS" ||
+			strings.HasPrefix(key, "RCLONE_") {
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	batchv1 "k8s.io/api/batch/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -103,6 +104,97 @@ var _ = Describe("Restic retain policy", func() {
 	})
 })
 
+var _ = Describe("Restic tuning options", func() {
+	Context("When tuning is omitted", func() {
+		It("has no options", func() {
+			Expect(generateGlobalOptions(nil)).To(BeEmpty())
+			Expect(generateBackupOptions(nil)).To(BeEmpty())
+		})
+	})
+	Context("When tuning is specified", func() {
+		It("has options that correspond", func() {
+			tuning := &volsyncv1alpha1.ResticTuningSpec{
+				Compression:      ptr.To("max"),
+				PackSizeMiB:      ptr.To[int32](64),
+				LimitUploadKiB:   ptr.To[int32](2048),
+				LimitDownloadKiB: ptr.To[int32](4096),
+				ReadConcurrency:  ptr.To[int32](4),
+				S3Connections:    ptr.To[int32](10),
+			}
+			global := generateGlobalOptions(tuning)
+			Expect(global).To(MatchRegexp("(^|\\s)--compression\\s+max(\\s|$)"))
+			Expect(global).To(MatchRegexp("(^|\\s)--pack-size\\s+64(\\s|$)"))
+			Expect(global).To(MatchRegexp("(^|\\s)--limit-upload\\s+2048(\\s|$)"))
+			Expect(global).To(MatchRegexp("(^|\\s)--limit-download\\s+4096(\\s|$)"))
+			Expect(global).To(MatchRegexp("(^|\\s)-o\\s+s3.connections=10(\\s|$)"))
+			Expect(global).NotTo(MatchRegexp("--read-concurrency"))
+			Expect(generateBackupOptions(tuning)).To(Equal("--read-concurrency 4"))
+		})
+		It("only has the options that are set", func() {
+			tuning := &volsyncv1alpha1.ResticTuningSpec{
+				LimitUploadKiB: ptr.To[int32](512),
+			}
+			Expect(generateGlobalOptions(tuning)).To(Equal("--limit-upload 512"))
+			Expect(generateBackupOptions(tuning)).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("Restic copyTo credentials", func() {
+	It("lists the credential env vars of a repository", func() {
+		copyToRepo := &corev1.Secret{Data: map[string][]byte{
+			"RESTIC_REPOSITORY":  []byte("b2:bucket:repo"),
+			"RESTIC_PASSWORD":    []byte("abc123"),
+			"B2_ACCOUNT_ID":      []byte("id"),
+			"RCLONE_CONFIG_TYPE": []byte("s3"),
+		}}
+		Expect(credentialEnvVarNames(copyToRepo)).To(Equal([]string{"B2_ACCOUNT_ID", "RCLONE_CONFIG_TYPE"}))
+	})
+})
+
+var _ = Describe("Restic copy result", func() {
+	It("has the snapshot and result reported by the mover", func() {
+		result, err := parseCopyResult(`{"snapshot":"0ff74383","result":"Failed"}`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Snapshot).To(Equal("0ff74383"))
+		Expect(result.Result).To(Equal(volsyncv1alpha1.MoverResultFailed))
+	})
+	It("is empty when the mover didn't report it", func() {
+		result, err := parseCopyResult("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Snapshot).To(BeEmpty())
+		Expect(result.Result).To(BeEmpty())
+	})
+	It("returns an error when the result can't be parsed", func() {
+		_, err := parseCopyResult("not json")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Restic restore preview", func() {
+	It("is empty when there was nothing to restore", func() {
+		preview, err := parseRestorePreview("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(preview.Snapshot).To(BeEmpty())
+		Expect(preview.FilesToAdd).To(BeZero())
+		Expect(preview.FilesToChange).To(BeZero())
+		Expect(preview.FilesToDelete).To(BeZero())
+	})
+	It("has the counts reported by the mover", func() {
+		preview, err := parseRestorePreview(
+			`{"snapshot":"0ff74383","filesToAdd":12,"filesToChange":3,"filesToDelete":5}`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(preview.Snapshot).To(Equal("0ff74383"))
+		Expect(preview.FilesToAdd).To(Equal(int64(12)))
+		Expect(preview.FilesToChange).To(Equal(int64(3)))
+		Expect(preview.FilesToDelete).To(Equal(int64(5)))
+	})
+	It("returns an error when the result can't be parsed", func() {
+		_, err := parseRestorePreview("not json")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
 var _ = Describe("Restic unlock", func() {
 	var m *Mover
 	var owner *corev1.ConfigMap
@@ -499,7 +591,7 @@ var _ = Describe("Restic as a source", func() {
 						repo.Data[k] = []byte("HELLO")
 					}
 					Expect(k8sClient.Update(ctx, repo)).To(Succeed())
-					s, e := mover.validateRepository(ctx)
+					s, e := mover.validateRepository(ctx, repo.Name)
 					if td.ok {
 						Expect(s).NotTo(BeNil())
 						Expect(e).NotTo(HaveOccurred())
@@ -532,6 +624,19 @@ var _ = Describe("Restic as a source", func() {
 				})
 			})
 
+			When("the data PVC is a block device", func() {
+				BeforeEach(func() {
+					dataPVC = sPVC.DeepCopy()
+					dataPVC.Spec.VolumeMode = ptr.To(corev1.PersistentVolumeBlock)
+				})
+				It("is still a filesystem", func() {
+					cache, err := mover.ensureCache(ctx, dataPVC, false)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(cache.Spec.VolumeMode).NotTo(BeNil())
+					Expect(*cache.Spec.VolumeMode).To(Equal(corev1.PersistentVolumeFilesystem))
+				})
+			})
+
 			When("no capacity is specified", func() {
 				BeforeEach(func() {
 					rs.Spec.Restic.CacheCapacity = nil
@@ -1313,7 +1418,7 @@ var _ = Describe("Restic as a source", func() {
 			})
 			When("it's the initial sync", func() {
 				It("should have only the backup action", func() {
-					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // hasn't completed
 					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1324,7 +1429,7 @@ var _ = Describe("Restic as a source", func() {
 					Expect(args).To(ConsistOf("backup"))
 				})
 				It("should use the specified container image", func() {
-					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // hasn't completed
 					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1335,7 +1440,7 @@ var _ = Describe("Restic as a source", func() {
 					Expect(args).To(Equal(defaultResticContainerImage))
 				})
 				It("should use the specified service account", func() {
-					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // hasn't completed
 					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1344,7 +1449,7 @@ var _ = Describe("Restic as a source", func() {
 					Expect(job.Spec.Template.Spec.ServiceAccountName).To(Equal(sa.Name))
 				})
 				It("should support pausing", func() {
-					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // hasn't completed
 					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1353,14 +1458,14 @@ var _ = Describe("Restic as a source", func() {
 					Expect(*job.Spec.Parallelism).To(Equal(int32(1)))
 
 					mover.paused = true
-					j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // hasn't completed
 					Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
 					Expect(*job.Spec.Parallelism).To(Equal(int32(0)))
 
 					mover.paused = false
-					j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // hasn't completed
 					Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
@@ -1368,7 +1473,7 @@ var _ = Describe("Restic as a source", func() {
 				})
 
 				It("Should have correct volumes", func() {
-					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // hasn't completed
 					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1396,8 +1501,41 @@ var _ = Describe("Restic as a source", func() {
 					Expect(foundCacheVolume).To(BeTrue())
 				})
 
+				When("the source PVC is a block device", func() {
+					var blockPVC *corev1.PersistentVolumeClaim
+					BeforeEach(func() {
+						blockPVC = &corev1.PersistentVolumeClaim{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "sblock",
+								Namespace: ns.Name,
+							},
+						}
+						sPVC.Spec.DeepCopyInto(&blockPVC.Spec)
+						blockPVC.Spec.VolumeMode = ptr.To(corev1.PersistentVolumeBlock)
+					})
+					JustBeforeEach(func() {
+						Expect(k8sClient.Create(ctx, blockPVC)).To(Succeed())
+					})
+					It("Should attach the data volume as a device", func() {
+						j, e := mover.ensureJob(ctx, cache, blockPVC, sa, repo, nil, nil)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(j).To(BeNil()) // hasn't completed
+						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+						job = &batchv1.Job{}
+						Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+
+						c := job.Spec.Template.Spec.Containers[0]
+						for _, volMount := range c.VolumeMounts {
+							Expect(volMount.Name).NotTo(Equal(dataVolumeName))
+						}
+						Expect(c.VolumeDevices).To(HaveLen(1))
+						Expect(c.VolumeDevices[0].Name).To(Equal(dataVolumeName))
+						Expect(c.VolumeDevices[0].DevicePath).To(Equal("/dev/block"))
+					})
+				})
+
 				It("Should not have a PodSecurityContext by default", func() {
-					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // hasn't completed
 					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1416,7 +1554,7 @@ var _ = Describe("Restic as a source", func() {
 					})
 
 					It("The job name should be shortened appropriately (should handle long CR names)", func() {
-						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).To(BeNil()) // hasn't completed
 
@@ -1442,7 +1580,7 @@ var _ = Describe("Restic as a source", func() {
 						}
 					})
 					It("Should appear in the mover Job", func() {
-						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).To(BeNil()) // hasn't completed
 						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1459,7 +1597,7 @@ var _ = Describe("Restic as a source", func() {
 				})
 
 				It("Should not have container resourceRequirements set by default", func() {
-					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // hasn't completed
 					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1480,7 +1618,7 @@ var _ = Describe("Restic as a source", func() {
 						}
 					})
 					It("Should use them in the mover job container", func() {
-						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).To(BeNil()) // hasn't completed
 						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1534,7 +1672,7 @@ var _ = Describe("Restic as a source", func() {
 						Expect(k8sClient.Create(ctx, moverVolPVC)).To(Succeed())
 					})
 					It("should mount the pvc in the container", func() {
-						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).To(BeNil()) // hasn't completed
 						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1563,7 +1701,7 @@ var _ = Describe("Restic as a source", func() {
 
 				When("The NS allows privileged movers", func() { // Already the case in this block
 					It("Should start a privileged mover", func() {
-						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).To(BeNil()) // hasn't completed
 						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1604,7 +1742,7 @@ var _ = Describe("Restic as a source", func() {
 					Expect(k8sClient.Create(ctx, roxPVC)).To(Succeed())
 				})
 				It("Mover job should mount the PVC as read-only", func() {
-					j, e := mover.ensureJob(ctx, cache, roxPVC, sa, repo, nil)
+					j, e := mover.ensureJob(ctx, cache, roxPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // hasn't completed
 					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1632,7 +1770,7 @@ var _ = Describe("Restic as a source", func() {
 						mover.unlock = "unlock-1"
 					})
 					It("should run a backup with unlock", func() {
-						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).To(BeNil()) // hasn't completed
 						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1645,7 +1783,7 @@ var _ = Describe("Restic as a source", func() {
 						// Mark completed
 						job.Status.Succeeded = int32(1)
 						Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
-						j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+						j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).NotTo(BeNil())
 						Expect(mover.sourceStatus.LastUnlocked).To(Equal("unlock-1"))
@@ -1660,7 +1798,7 @@ var _ = Describe("Restic as a source", func() {
 					})
 
 					It("should run a backup without running unlock", func() {
-						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).To(BeNil()) // hasn't completed
 						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1673,7 +1811,7 @@ var _ = Describe("Restic as a source", func() {
 						// Mark completed
 						job.Status.Succeeded = int32(1)
 						Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
-						j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+						j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).NotTo(BeNil())
 						// LastUnlocked should still be the previous value
@@ -1690,7 +1828,7 @@ var _ = Describe("Restic as a source", func() {
 					})
 
 					It("should run a backup with unlock", func() {
-						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).To(BeNil()) // hasn't completed
 						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1703,7 +1841,7 @@ var _ = Describe("Restic as a source", func() {
 						// Mark completed
 						job.Status.Succeeded = int32(1)
 						Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
-						j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+						j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).NotTo(BeNil())
 						// LastUnlocked should be updated with the new value
@@ -1721,7 +1859,7 @@ var _ = Describe("Restic as a source", func() {
 					It("should run a backup without running unlock", func() {
 						mover.unlock = ""
 
-						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).To(BeNil()) // hasn't completed
 						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1735,7 +1873,7 @@ var _ = Describe("Restic as a source", func() {
 						// Mark completed
 						job.Status.Succeeded = int32(1)
 						Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
-						j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+						j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).NotTo(BeNil())
 
@@ -1756,7 +1894,7 @@ var _ = Describe("Restic as a source", func() {
 					}
 				})
 				It("should have the backup and prune actions", func() {
-					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // hasn't completed
 					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1769,19 +1907,386 @@ var _ = Describe("Restic as a source", func() {
 					// Mark completed
 					job.Status.Succeeded = int32(1)
 					Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
-					j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).NotTo(BeNil())
 					Expect(mover.sourceStatus.LastPruned.After(lastMonth.Time)).To(BeTrue())
 				})
 			})
 
+			When("copyTo is set", func() {
+				var copyToRepo *corev1.Secret
+				BeforeEach(func() {
+					copyToRepo = &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "copyto-secret",
+							Namespace: ns.Name,
+						},
+						StringData: map[string]string{
+							"RESTIC_REPOSITORY": "s3:http://offsite.example.com/restic-repo",
+							"RESTIC_PASSWORD":   "abc123",
+							"AWS_ACCESS_KEY_ID": "offsite-access-key",
+						},
+					}
+				})
+				JustBeforeEach(func() {
+					Expect(k8sClient.Create(ctx, copyToRepo)).To(Succeed())
+					mover.copyTo = &volsyncv1alpha1.ResticCopyToSpec{
+						Repository: copyToRepo.GetName(),
+						Retain: &volsyncv1alpha1.ResticRetainPolicy{
+							Daily: ptr.To[int32](7),
+						},
+					}
+				})
+				It("should have the backup and copy actions", func() {
+					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, copyToRepo, nil)
+					Expect(e).NotTo(HaveOccurred())
+					Expect(j).To(BeNil()) // hasn't completed
+					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+					job = &batchv1.Job{}
+					Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+					Expect(job.Spec.Template.Spec.Containers).ToNot(BeEmpty())
+					args := job.Spec.Template.Spec.Containers[0].Args
+					Expect(args).To(Equal([]string{"backup", "copy"}))
+				})
+				It("should set the env vars for the copyTo repository", func() {
+					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, copyToRepo, nil)
+					Expect(e).NotTo(HaveOccurred())
+					Expect(j).To(BeNil()) // hasn't completed
+					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+					job = &batchv1.Job{}
+					Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+
+					env := job.Spec.Template.Spec.Containers[0].Env
+					Expect(env).To(ContainElement(corev1.EnvVar{
+						Name:  "COPY_TO_FORGET_OPTIONS",
+						Value: " --keep-daily 7",
+					}))
+					for _, key := range []string{"RESTIC_REPOSITORY", "RESTIC_PASSWORD", "AWS_ACCESS_KEY_ID"} {
+						Expect(env).To(ContainElement(corev1.EnvVar{
+							Name: "COPY_TO_" + key,
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: copyToRepo.GetName(),
+									},
+									Key:      key,
+									Optional: ptr.To(key == "AWS_ACCESS_KEY_ID"),
+								},
+							},
+						}))
+					}
+				})
+				When("the repositories use different credentials", func() {
+					BeforeEach(func() {
+						repo.StringData = map[string]string{
+							"RESTIC_REPOSITORY": "s3:http://minio.example.com/restic-repo",
+							"AWS_ACCESS_KEY_ID": "minio-access-key",
+						}
+					})
+					It("should pass the credentials of both repositories", func() {
+						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, copyToRepo, nil)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(j).To(BeNil()) // hasn't completed
+						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+						job = &batchv1.Job{}
+						Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+
+						env := job.Spec.Template.Spec.Containers[0].Env
+						for name, secret := range map[string]*corev1.Secret{
+							"AWS_ACCESS_KEY_ID":         repo,
+							"COPY_TO_AWS_ACCESS_KEY_ID": copyToRepo,
+						} {
+							Expect(env).To(ContainElement(corev1.EnvVar{
+								Name: name,
+								ValueFrom: &corev1.EnvVarSource{
+									SecretKeyRef: &corev1.SecretKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{
+											Name: secret.GetName(),
+										},
+										Key:      "AWS_ACCESS_KEY_ID",
+										Optional: ptr.To(true),
+									},
+								},
+							}))
+						}
+						// The mover passes these to restic copy with the
+						// RESTIC_FROM_ prefix
+						Expect(env).To(ContainElement(corev1.EnvVar{
+							Name:  "REPOSITORY_ENV_VARS",
+							Value: "AWS_ACCESS_KEY_ID",
+						}))
+					})
+				})
+				It("should update the copyTo status once the job completes", func() {
+					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, copyToRepo, nil)
+					Expect(e).NotTo(HaveOccurred())
+					Expect(j).To(BeNil()) // hasn't completed
+					Expect(mover.sourceStatus.CopyTo).To(BeNil())
+					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+					job = &batchv1.Job{}
+					Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+					// Mark completed
+					job.Status.Succeeded = int32(1)
+					Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+					j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, copyToRepo, nil)
+					Expect(e).NotTo(HaveOccurred())
+					Expect(j).NotTo(BeNil())
+					Expect(mover.sourceStatus.CopyTo).NotTo(BeNil())
+					Expect(mover.sourceStatus.CopyTo.Repository).To(Equal(copyToRepo.GetName()))
+					Expect(mover.sourceStatus.CopyTo.LastCopied).NotTo(BeNil())
+					Expect(mover.sourceStatus.CopyTo.Result).To(Equal(volsyncv1alpha1.MoverResultSuccessful))
+				})
+				When("the copyTo repository uses GCS", func() {
+					BeforeEach(func() {
+						copyToRepo.StringData["GOOGLE_APPLICATION_CREDENTIALS"] = "dummy"
+					})
+					It("should mount the credentials of the copyTo repository", func() {
+						j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, copyToRepo, nil)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(j).To(BeNil()) // hasn't completed
+						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+						job = &batchv1.Job{}
+						Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+
+						container := job.Spec.Template.Spec.Containers[0]
+						Expect(container.Env).To(ContainElement(corev1.EnvVar{
+							Name:  "COPY_TO_GOOGLE_APPLICATION_CREDENTIALS",
+							Value: path.Join(copyToCredentialDir, gcsCredentialFile),
+						}))
+						Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+							Name:      "copy-to-gcs-credentials",
+							MountPath: copyToCredentialDir,
+						}))
+						found := false
+						for _, v := range job.Spec.Template.Spec.Volumes {
+							if v.Name == "copy-to-gcs-credentials" {
+								found = true
+								Expect(v.Secret.SecretName).To(Equal(copyToRepo.GetName()))
+							}
+						}
+						Expect(found).To(BeTrue())
+					})
+				})
+			})
+
+			When("maintenance is set", func() {
+				var maintRepo *corev1.Secret
+				var maintJobName string
+				BeforeEach(func() {
+					maintRepo = &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "maint-secret",
+							Namespace: ns.Name,
+						},
+						StringData: map[string]string{
+							"RESTIC_REPOSITORY": "s3:http://minio.example.com/restic-repo",
+							"RESTIC_PASSWORD":   "abc123",
+						},
+					}
+					maintJobName = "volsync-restic-maintenance-" +
+						utils.GetHashedName("s3:http://minio.example.com/restic-repo")
+					// The maintenance Leases are kept in the operator namespace
+					Expect(os.Setenv(utils.VolSyncNamespaceEnvVar, ns.Name)).To(Succeed())
+					DeferCleanup(os.Unsetenv, utils.VolSyncNamespaceEnvVar)
+				})
+				JustBeforeEach(func() {
+					Expect(k8sClient.Create(ctx, maintRepo)).To(Succeed())
+					mover.maintenance = &volsyncv1alpha1.ResticMaintenanceSpec{
+						Schedule: "@daily",
+						Check:    true,
+					}
+				})
+				It("should not prune as part of the backup", func() {
+					mover.sourceStatus.LastPruned = &metav1.Time{Time: time.Now().Add(-30 * 24 * time.Hour)}
+					Expect(mover.shouldPrune(time.Now())).To(BeFalse())
+				})
+				It("should wait until maintenance is due", func() {
+					result, err := mover.Maintain(ctx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.RetryAfter).NotTo(BeNil())
+					Expect(*result.RetryAfter).To(BeNumerically(">", 0))
+					Expect(mover.sourceStatus.NextMaintenance).NotTo(BeNil())
+
+					jobs := &batchv1.JobList{}
+					Expect(k8sClient.List(ctx, jobs, client.InNamespace(ns.Name))).To(Succeed())
+					Expect(jobs.Items).To(BeEmpty())
+				})
+				It("should run prune and check in a separate job", func() {
+					j, e := mover.ensureMaintenanceJob(ctx, sa, maintRepo, nil, nil)
+					Expect(e).NotTo(HaveOccurred())
+					Expect(j).To(BeNil()) // hasn't completed
+					nsn := types.NamespacedName{Name: maintJobName, Namespace: ns.Name}
+					job = &batchv1.Job{}
+					Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+					Expect(job.Labels).To(HaveKey("volsync.backube/restic-repository"))
+					// Maintenance jobs are not removed by cleanup after a sync
+					Expect(job.Labels).NotTo(HaveKey("volsync.backube/cleanup"))
+
+					c := job.Spec.Template.Spec.Containers[0]
+					Expect(c.Args).To(Equal([]string{"prune", "check"}))
+					for _, vol := range job.Spec.Template.Spec.Volumes {
+						Expect(vol.PersistentVolumeClaim).To(BeNil())
+					}
+
+					// Mark completed
+					job.Status.Succeeded = int32(1)
+					Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+					j, e = mover.ensureMaintenanceJob(ctx, sa, maintRepo, nil, nil)
+					Expect(e).NotTo(HaveOccurred())
+					Expect(j).NotTo(BeNil())
+					Expect(mover.sourceStatus.LatestMaintenanceStatus).NotTo(BeNil())
+				})
+				It("should wait for the lock held by a backup", func() {
+					_, e := mover.ensureMaintenanceJob(ctx, sa, maintRepo, nil, nil)
+					Expect(e).NotTo(HaveOccurred())
+					nsn := types.NamespacedName{Name: maintJobName, Namespace: ns.Name}
+					job = &batchv1.Job{}
+					Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+					Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+						corev1.EnvVar{Name: "RETRY_LOCK", Value: retryLock}))
+				})
+				When("maintenance of the repository is running", func() {
+					JustBeforeEach(func() {
+						_, e := mover.ensureMaintenanceJob(ctx, sa, maintRepo, nil, nil)
+						Expect(e).NotTo(HaveOccurred())
+					})
+					It("should not start a backup until it has completed", func() {
+						wait, e := mover.shouldWaitForMaintenance(ctx, maintRepo)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(wait).To(BeTrue())
+
+						// Mark completed
+						nsn := types.NamespacedName{Name: maintJobName, Namespace: ns.Name}
+						job = &batchv1.Job{}
+						Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+						job.Status.Succeeded = int32(1)
+						Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+						// The lease is released once the job is seen to have completed
+						j, e := mover.ensureMaintenanceJob(ctx, sa, maintRepo, nil, nil)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(j).NotTo(BeNil())
+						wait, e = mover.shouldWaitForMaintenance(ctx, maintRepo)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(wait).To(BeFalse())
+					})
+					It("should let a backup that has started wait for the lock", func() {
+						j, e := mover.ensureJob(ctx, cache, sPVC, sa, maintRepo, nil, nil)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(j).To(BeNil()) // hasn't completed
+						wait, e := mover.shouldWaitForMaintenance(ctx, maintRepo)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(wait).To(BeFalse())
+
+						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+						job = &batchv1.Job{}
+						Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+						Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+							corev1.EnvVar{Name: "RETRY_LOCK", Value: retryLock}))
+					})
+				})
+				When("another owner is running maintenance on the same repository", func() {
+					var other *Mover
+					JustBeforeEach(func() {
+						// The same repository, used from another namespace
+						otherOwner := rs.DeepCopy()
+						otherOwner.Namespace = "other-namespace"
+						other = &Mover{}
+						*other = *mover
+						other.owner = otherOwner
+						repositoryHash := utils.GetHashedName("s3:http://minio.example.com/restic-repo")
+						acquired, e := other.acquireMaintenanceLease(ctx, repositoryHash)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(acquired).To(BeTrue())
+					})
+					It("should wait for it to finish", func() {
+						j, e := mover.ensureMaintenanceJob(ctx, sa, maintRepo, nil, nil)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(j).To(BeNil())
+						nsn := types.NamespacedName{Name: maintJobName, Namespace: ns.Name}
+						Expect(kerrors.IsNotFound(k8sClient.Get(ctx, nsn, &batchv1.Job{}))).To(BeTrue())
+						wait, e := mover.shouldWaitForMaintenance(ctx, maintRepo)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(wait).To(BeTrue())
+
+						// Once released, maintenance can run
+						repositoryHash := utils.GetHashedName("s3:http://minio.example.com/restic-repo")
+						Expect(other.releaseMaintenanceLease(ctx, repositoryHash)).To(Succeed())
+						j, e = mover.ensureMaintenanceJob(ctx, sa, maintRepo, nil, nil)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(j).To(BeNil()) // hasn't completed
+						Expect(k8sClient.Get(ctx, nsn, &batchv1.Job{})).To(Succeed())
+					})
+					It("should take over the lease once it has expired", func() {
+						lease := &coordinationv1.Lease{}
+						Expect(k8sClient.Get(ctx, types.NamespacedName{
+							Name:      maintenanceLeaseName(utils.GetHashedName("s3:http://minio.example.com/restic-repo")),
+							Namespace: ns.Name,
+						}, lease)).To(Succeed())
+						lease.Spec.RenewTime = &metav1.MicroTime{Time: time.Now().Add(-2 * maintenanceLeaseDuration)}
+						Expect(k8sClient.Update(ctx, lease)).To(Succeed())
+
+						_, e := mover.ensureMaintenanceJob(ctx, sa, maintRepo, nil, nil)
+						Expect(e).NotTo(HaveOccurred())
+						nsn := types.NamespacedName{Name: maintJobName, Namespace: ns.Name}
+						Expect(k8sClient.Get(ctx, nsn, &batchv1.Job{})).To(Succeed())
+					})
+				})
+				It("should give the lease to only one of two sources racing for it", func() {
+					repositoryHash := utils.GetHashedName("s3:http://minio.example.com/restic-repo")
+					movers := []*Mover{}
+					for _, namespace := range []string{"first-namespace", "second-namespace"} {
+						owner := rs.DeepCopy()
+						owner.Namespace = namespace
+						m := &Mover{}
+						*m = *mover
+						m.owner = owner
+						movers = append(movers, m)
+					}
+
+					results := make(chan bool, len(movers))
+					start := make(chan struct{})
+					for _, m := range movers {
+						go func(m *Mover) {
+							defer GinkgoRecover()
+							<-start
+							acquired, e := m.acquireMaintenanceLease(ctx, repositoryHash)
+							Expect(e).NotTo(HaveOccurred())
+							results <- acquired
+						}(m)
+					}
+					close(start)
+					acquired := 0
+					for range movers {
+						if <-results {
+							acquired++
+						}
+					}
+					Expect(acquired).To(Equal(1))
+
+					lease := &coordinationv1.Lease{}
+					Expect(k8sClient.Get(ctx, types.NamespacedName{
+						Name:      maintenanceLeaseName(repositoryHash),
+						Namespace: ns.Name,
+					}, lease)).To(Succeed())
+					holder := ptr.Deref(lease.Spec.HolderIdentity, "")
+					Expect(holder).To(BeElementOf("first-namespace/"+rs.Name, "second-namespace/"+rs.Name))
+
+					// The holder renews the lease, the other keeps waiting
+					for _, m := range movers {
+						acquired, e := m.acquireMaintenanceLease(ctx, repositoryHash)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(acquired).To(Equal(m.leaseHolderIdentity() == holder))
+					}
+				})
+			})
+
 			When("Doing a sync when the job already exists", func() {
 				JustBeforeEach(func() {
 					mover.containerImage = "my-restic-mover-image"
 
 					// Initial job creation
-					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // hasn't completed
 
@@ -1799,7 +2304,7 @@ var _ = Describe("Restic as a source", func() {
 					mover.containerImage = myUpdatedImage
 
 					// Mover should get immutable err for updating the image and then delete the job
-					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 					Expect(e).To(HaveOccurred())
 					Expect(j).To(BeNil())
 
@@ -1809,7 +2314,7 @@ var _ = Describe("Restic as a source", func() {
 					Expect(kerrors.IsNotFound(k8sClient.Get(ctx, nsn, job))).To(BeTrue())
 
 					// Run ensureJob again as the reconciler would do - should recreate the job
-					j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // job hasn't completed
 
@@ -1822,7 +2327,7 @@ var _ = Describe("Restic as a source", func() {
 
 			When("the job has failed", func() {
 				It("should be restarted", func() {
-					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // hasn't completed
 					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -1832,14 +2337,14 @@ var _ = Describe("Restic as a source", func() {
 					Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
 
 					// 1st reconcile should delete the job
-					j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil())
 					// Job should be deleted
 					Expect(kerrors.IsNotFound(k8sClient.Get(ctx, nsn, job))).To(BeTrue())
 
 					// 2nd reconcile should recreate the job
-					j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					j, e = mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // hasn't completed
 					Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
@@ -1899,7 +2404,7 @@ var _ = Describe("Restic as a source", func() {
 			})
 
 			It("Should run unprivileged by default", func() {
-				j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+				j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil, nil)
 				Expect(e).NotTo(HaveOccurred())
 				Expect(j).To(BeNil()) // hasn't completed
 				nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -2202,7 +2707,7 @@ var _ = Describe("Restic as a destination", func() {
 			})
 			When("it's the initial sync", func() {
 				It("should have only the restore action", func() {
-					j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil)
+					j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // hasn't completed
 					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -2229,7 +2734,7 @@ var _ = Describe("Restic as a destination", func() {
 					Expect(err).NotTo(HaveOccurred())
 
 					// Common checks for customCA (configCA as secret or configmap)
-					j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, customCaObj)
+					j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil, customCaObj)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // hasn't completed
 					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -2308,7 +2813,7 @@ var _ = Describe("Restic as a destination", func() {
 				})
 
 				It("Should set the env vars in the mover job pod", func() {
-					j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil)
+					j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil, nil)
 					Expect(e).NotTo(HaveOccurred())
 					Expect(j).To(BeNil()) // hasn't completed
 					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -2330,7 +2835,7 @@ var _ = Describe("Restic as a destination", func() {
 			Context("Handling GCS credentials", func() {
 				When("no credentials are provided", func() {
 					It("shouldn't mount the Secret", func() {
-						j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil)
+						j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).To(BeNil()) // hasn't completed
 						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -2355,7 +2860,7 @@ var _ = Describe("Restic as a destination", func() {
 						}
 					})
 					It("should mount the Secret", func() {
-						j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil)
+						j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).To(BeNil()) // hasn't completed
 						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -2411,7 +2916,7 @@ var _ = Describe("Restic as a destination", func() {
 						}
 					})
 					It("should only set env vars that are present in the secret", func() {
-						j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil)
+						j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).To(BeNil()) // hasn't completed
 						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -2456,7 +2961,7 @@ var _ = Describe("Restic as a destination", func() {
 						}
 					})
 					It("should set the Azure Workload Identity env vars in the job", func() {
-						j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil)
+						j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).To(BeNil()) // hasn't completed
 						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -2480,7 +2985,7 @@ var _ = Describe("Restic as a destination", func() {
 			Context("Restore options", func() {
 				When("No restore options are specified", func() {
 					It("should set env vars related to restore options with defaults", func() {
-						j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil)
+						j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).To(BeNil()) // hasn't completed
 						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -2525,7 +3030,7 @@ var _ = Describe("Restic as a destination", func() {
 						rd.Spec.Restic.EnableFileDeletion = true
 					})
 					It("should set RESTORE_OPTIONS env var with delete flag", func() {
-						j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil)
+						j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).To(BeNil()) // hasn't completed
 						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -2544,12 +3049,42 @@ var _ = Describe("Restic as a destination", func() {
 						Expect(restoreOptions.Value).To(Equal("--delete"))
 					})
 				})
+				When("dryRun is specified", func() {
+					BeforeEach(func() {
+						rd.Spec.Restic.DryRun = true
+					})
+					It("should preview the restore without modifying the data", func() {
+						j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil, nil)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(j).To(BeNil()) // hasn't completed
+						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+						job = &batchv1.Job{}
+						Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+
+						envVars := job.Spec.Template.Spec.Containers[0].Env
+						Expect(envVars).To(ContainElement(corev1.EnvVar{Name: "RESTORE_PREVIEW", Value: "1"}))
+
+						for _, vol := range job.Spec.Template.Spec.Volumes {
+							if vol.Name == dataVolumeName {
+								Expect(vol.PersistentVolumeClaim.ReadOnly).To(BeTrue())
+							}
+						}
+					})
+					It("should be rejected for a block volume", func() {
+						Expect(mover.validateDryRun(dPVC)).To(Succeed())
+						blockPVC := dPVC.DeepCopy()
+						blockPVC.Spec.VolumeMode = ptr.To(corev1.PersistentVolumeBlock)
+						err := mover.validateDryRun(blockPVC)
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("not supported for block volumes"))
+					})
+				})
 			})
 
 			Context("Cluster wide proxy settings", func() {
 				When("no proxy env vars are set on the volsync controller", func() {
 					It("shouldn't set any proxy env vars on the mover job", func() {
-						j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil)
+						j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).To(BeNil()) // hasn't completed
 						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -2580,7 +3115,7 @@ var _ = Describe("Restic as a destination", func() {
 					})
 
 					It("should set the corresponding proxy env vars on the mover job", func() {
-						j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil)
+						j, e := mover.ensureJob(ctx, cache, dPVC, sa, repo, nil, nil)
 						Expect(e).NotTo(HaveOccurred())
 						Expect(j).To(BeNil()) // hasn't completed
 						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
@@ -147,6 +147,14 @@ func (rb *Builder) FromSource(client client.Client, logger logr.Logger,
 			MoverPodLabels:       source.Spec.Rsync.MoverPodLabels,
 			MoverResources:       source.Spec.Rsync.MoverResources,
 		},
+		tuning:           source.Spec.Rsync.Tuning,
+		excludePatterns:  source.Spec.Rsync.ExcludePatterns,
+		excludeConfigMap: source.Spec.Rsync.ExcludeConfigMap,
+		keyRotation: keyRotationSpec{
+			interval:    source.Spec.Rsync.KeyRotationInterval,
+			token:       source.Spec.Rsync.KeyRotationToken,
+			gracePeriod: source.Spec.Rsync.KeyRotationGracePeriod,
+		},
 	}, nil
 }
 
@@ -216,5 +224,10 @@ func (rb *Builder) FromDestination(client client.Client, logger logr.Logger,
 			MoverPodLabels:       destination.Spec.Rsync.MoverPodLabels,
 			MoverResources:       destination.Spec.Rsync.MoverResources,
 		},
+		keyRotation: keyRotationSpec{
+			interval:    destination.Spec.Rsync.KeyRotationInterval,
+			token:       destination.Spec.Rsync.KeyRotationToken,
+			gracePeriod: destination.Spec.Rsync.KeyRotationGracePeriod,
+		},
 	}, nil
 }
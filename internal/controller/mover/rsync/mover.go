@@ -52,6 +52,13 @@ const (
 	volSyncRsyncPrefix = mover.VolSyncPrefix + "rsync-"
 )
 
+// keyRotationSpec holds the rotation settings for generated SSH keys
+type keyRotationSpec struct {
+	interval    *metav1.Duration
+	token       *string
+	gracePeriod *metav1.Duration
+}
+
 // Mover is the reconciliation logic for the Rsync-based data mover.
 type Mover struct {
 	client             client.Client
@@ -62,6 +69,7 @@ type Mover struct {
 	saHandler          utils.SAHandler
 	containerImage     string
 	sshKeys            *string
+	keyRotation        keyRotationSpec
 	serviceType        *corev1.ServiceType
 	serviceAnnotations map[string]string
 	address            *string
@@ -72,7 +80,10 @@ type Mover struct {
 	latestMoverStatus  *volsyncv1alpha1.MoverStatus
 	moverConfig        volsyncv1alpha1.MoverConfig
 	// Source-only fields
-	sourceStatus *volsyncv1alpha1.ReplicationSourceRsyncStatus
+	sourceStatus     *volsyncv1alpha1.ReplicationSourceRsyncStatus
+	tuning           *volsyncv1alpha1.RsyncTuningSpec
+	excludePatterns  []string
+	excludeConfigMap *volsyncv1alpha1.ConfigMapKeySpec
 	// Destination-only fields
 	destStatus     *volsyncv1alpha1.ReplicationDestinationRsyncStatus
 	cleanupTempPVC bool
@@ -104,6 +115,13 @@ func (m *Mover) Synchronize(ctx context.Context) (mover.Result, error) {
 		return mover.InProgress(), err
 	}
 
+	if m.isSource {
+		if err := utils.ValidateRsyncExcludes(ctx, m.client, m.logger, m.owner.GetNamespace(),
+			m.excludePatterns, m.excludeConfigMap); err != nil {
+			return mover.InProgress(), err
+		}
+	}
+
 	// Ensure service (if required) and publish the address in the status
 	cont, err := m.ensureServiceAndPublishAddress(ctx)
 	if !cont || err != nil {
@@ -220,6 +238,33 @@ func (m *Mover) updateStatusSSHKeys(sshKeys *string) {
 	}
 }
 
+func (m *Mover) statusKeyRotation() keyRotationStatus {
+	if m.isSource {
+		return keyRotationStatus{
+			LastRotation:          m.sourceStatus.LastKeyRotation,
+			Token:                 m.sourceStatus.KeyRotationToken,
+			PreviousKeyExpiration: m.sourceStatus.PreviousKeyExpiration,
+		}
+	}
+	return keyRotationStatus{
+		LastRotation:          m.destStatus.LastKeyRotation,
+		Token:                 m.destStatus.KeyRotationToken,
+		PreviousKeyExpiration: m.destStatus.PreviousKeyExpiration,
+	}
+}
+
+func (m *Mover) updateStatusKeyRotation(rotation keyRotationStatus) {
+	if m.isSource {
+		m.sourceStatus.LastKeyRotation = rotation.LastRotation
+		m.sourceStatus.KeyRotationToken = rotation.Token
+		m.sourceStatus.PreviousKeyExpiration = rotation.PreviousKeyExpiration
+	} else {
+		m.destStatus.LastKeyRotation = rotation.LastRotation
+		m.destStatus.KeyRotationToken = rotation.Token
+		m.destStatus.PreviousKeyExpiration = rotation.PreviousKeyExpiration
+	}
+}
+
 // Will ensure the secret exists or create secrets if necessary
 //   - If secrets are created, will expose the appropriate secret in the status (src secret if ReplicationDestination,
 //     dest secret if ReplicationSource)
@@ -246,12 +291,20 @@ func (m *Mover) ensureSecrets(ctx context.Context) (*string, error) {
 
 	// otherwise, we need to create our own
 	keyInfo := rsyncSSHKeys{
-		Context:      ctx,
-		Client:       m.client,
-		Owner:        m.owner,
-		NameTemplate: volSyncRsyncPrefix + m.direction(),
+		Context:          ctx,
+		Client:           m.client,
+		Owner:            m.owner,
+		NameTemplate:     volSyncRsyncPrefix + m.direction(),
+		IsSource:         m.isSource,
+		RotationInterval: m.keyRotation.interval,
+		RotationToken:    m.keyRotation.token,
+		GracePeriod:      m.keyRotation.gracePeriod,
+		Rotation:         m.statusKeyRotation(),
 	}
 	cont, err := keyInfo.Reconcile(m.logger)
+	if err == nil {
+		m.updateStatusKeyRotation(keyInfo.Rotation)
+	}
 	if !cont || err != nil {
 		m.updateStatusSSHKeys(nil)
 		return nil, err
@@ -396,6 +449,9 @@ func (m *Mover) ensureJob(ctx context.Context, dataPVC *corev1.PersistentVolumeC
 				}
 			}
 
+			// Options to tune the rsync transfer
+			containerEnv = append(containerEnv, utils.RsyncTuningEnvVars(m.tuning)...)
+
 			// Set container cmd for the replicationSource job
 			containerCmd = []string{"/bin/bash", "-c", "/mover-rsync/source.sh"}
 
@@ -479,6 +535,10 @@ func (m *Mover) ensureJob(ctx context.Context, dataPVC *corev1.PersistentVolumeC
 			job.Spec.Template.Spec.Tolerations = affinity.Tolerations
 		}
 
+		if m.isSource {
+			utils.UpdatePodSpecWithRsyncExcludes(&job.Spec.Template.Spec, m.excludePatterns, m.excludeConfigMap)
+		}
+
 		// Update the job podLabels and resourceRequirements (if specified)
 		utils.UpdatePodTemplateSpecFromMoverConfig(&job.Spec.Template, m.moverConfig, corev1.ResourceRequirements{})
 
@@ -24,6 +24,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -111,6 +112,21 @@ func updateMap(map1, map2 map[string]string) {
 	}
 }
 
+const (
+	// Annotation on the main secret recording when the previous source key
+	// stops being accepted after a key rotation
+	previousKeyExpirationAnnotation = utils.VolsyncLabelPrefix + "/previous-key-expiration"
+	defaultKeyRotationGracePeriod   = 24 * time.Hour
+)
+
+// keyRotationStatus is the state of the key rotation that gets reflected in
+// the owner's status
+type keyRotationStatus struct {
+	LastRotation          *metav1.Time
+	Token                 *string
+	PreviousKeyExpiration *metav1.Time
+}
+
 type rsyncSSHKeys struct {
 	Context      context.Context
 	Client       client.Client
@@ -119,6 +135,13 @@ type rsyncSSHKeys struct {
 	MainSecret   *corev1.Secret
 	SrcSecret    *corev1.Secret
 	DestSecret   *corev1.Secret
+	// Whether the keys are generated by a ReplicationSource
+	IsSource bool
+	// Key rotation settings
+	RotationInterval *metav1.Duration
+	RotationToken    *string
+	GracePeriod      *metav1.Duration
+	Rotation         keyRotationStatus
 }
 
 func (k *rsyncSSHKeys) Reconcile(l logr.Logger) (bool, error) {
@@ -172,9 +195,9 @@ func (k *rsyncSSHKeys) ensureMainSecret(l logr.Logger) (bool, error) {
 			}
 			return false, err
 		}
-		// Secret is valid, we're done
+		// Secret is valid, rotate the keys if necessary
 		logger.V(1).Info("secret is valid")
-		return true, nil
+		return k.ensureKeyRotation(logger)
 	}
 
 	// Need to create the secret
@@ -186,6 +209,9 @@ func (k *rsyncSSHKeys) ensureMainSecret(l logr.Logger) (bool, error) {
 		l.Error(err, "unable to create secret")
 		return false, err
 	}
+	// A token that is present when the keys are first generated does not
+	// require a rotation
+	k.Rotation = keyRotationStatus{Token: k.RotationToken}
 
 	l.V(1).Info("created secret")
 	return false, nil
@@ -238,7 +264,115 @@ func (k *rsyncSSHKeys) generateMainSecret(l logr.Logger) error {
 	return nil
 }
 
-func (k *rsyncSSHKeys) ensureSecret(l logr.Logger, secret *corev1.Secret, keys []string) (bool, error) {
+// ensureKeyRotation rotates the source key pair when the rotation interval has
+// elapsed or the rotation token has changed. The previous public key is kept
+// in the main secret until the grace period ends so that the destination
+// accepts both keys while the new one is distributed. The destination's host
+// key is not rotated.
+func (k *rsyncSSHKeys) ensureKeyRotation(l logr.Logger) (bool, error) {
+	now := time.Now()
+	k.Rotation.PreviousKeyExpiration = k.previousKeyExpiration(l)
+
+	if k.rotationDue(now) {
+		if err := k.rotateKeys(l, now); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	expiration := k.Rotation.PreviousKeyExpiration
+	if expiration == nil || now.Before(expiration.Time) {
+		return true, nil
+	}
+
+	// Grace period has ended, stop accepting the previous key
+	delete(k.MainSecret.Data, "source.previous")
+	delete(k.MainSecret.Data, "source.pub.previous")
+	delete(k.MainSecret.Annotations, previousKeyExpirationAnnotation)
+	if err := k.Client.Update(k.Context, k.MainSecret); err != nil {
+		l.Error(err, "unable to remove previous ssh keys")
+		return false, err
+	}
+	k.Rotation.PreviousKeyExpiration = nil
+	l.Info("grace period ended, removed previous ssh keys")
+	return true, nil
+}
+
+func (k *rsyncSSHKeys) rotationDue(now time.Time) bool {
+	if k.RotationToken != nil &&
+		(k.Rotation.Token == nil || *k.Rotation.Token != *k.RotationToken) {
+		return true
+	}
+	if k.RotationInterval == nil || k.RotationInterval.Duration <= 0 {
+		return false
+	}
+	// If we've never rotated, the 1st rotation is scheduled from key creation
+	lastRotation := k.MainSecret.GetCreationTimestamp().Time
+	if !k.Rotation.LastRotation.IsZero() {
+		lastRotation = k.Rotation.LastRotation.Time
+	}
+	return !now.Before(lastRotation.Add(k.RotationInterval.Duration))
+}
+
+func (k *rsyncSSHKeys) rotateKeys(l logr.Logger, now time.Time) error {
+	priv, pub, err := generateKeyPair(k.Context, l)
+	if err != nil {
+		l.Error(err, "unable to generate source ssh keys")
+		return err
+	}
+
+	gracePeriod := defaultKeyRotationGracePeriod
+	if k.GracePeriod != nil {
+		gracePeriod = k.GracePeriod.Duration
+	}
+	expiration := metav1.NewTime(now.Add(gracePeriod).Truncate(time.Second))
+
+	k.MainSecret.Data["source.previous"] = k.MainSecret.Data["source"]
+	k.MainSecret.Data["source.pub.previous"] = k.MainSecret.Data["source.pub"]
+	k.MainSecret.Data["source"] = priv
+	k.MainSecret.Data["source.pub"] = pub
+	if k.MainSecret.Annotations == nil {
+		k.MainSecret.Annotations = map[string]string{}
+	}
+	k.MainSecret.Annotations[previousKeyExpirationAnnotation] = expiration.UTC().Format(time.RFC3339)
+	if err := k.Client.Update(k.Context, k.MainSecret); err != nil {
+		l.Error(err, "unable to update secret with rotated ssh keys")
+		return err
+	}
+
+	k.Rotation = keyRotationStatus{
+		LastRotation:          &metav1.Time{Time: now},
+		Token:                 k.RotationToken,
+		PreviousKeyExpiration: &expiration,
+	}
+	l.Info("rotated ssh keys", "previousKeyExpiration", expiration)
+	return nil
+}
+
+// previousKeyExpiration returns the end of the grace period of the previous
+// key, or nil if there is no previous key
+func (k *rsyncSSHKeys) previousKeyExpiration(l logr.Logger) *metav1.Time {
+	if _, ok := k.MainSecret.Data["source.pub.previous"]; !ok {
+		return nil
+	}
+	expiration, err := time.Parse(time.RFC3339, k.MainSecret.GetAnnotations()[previousKeyExpirationAnnotation])
+	if err != nil {
+		// Without a valid expiration, end the grace period now
+		l.Error(err, "invalid previous key expiration")
+		return &metav1.Time{}
+	}
+	return &metav1.Time{Time: expiration}
+}
+
+func (k *rsyncSSHKeys) hasPreviousKey() bool {
+	_, ok := k.MainSecret.Data["source.previous"]
+	return ok
+}
+
+// ensureSecret copies the keys from the main secret into the secret. The keys
+// map the field name in the secret to the field name in the main secret.
+// Fields that are missing from the main secret are removed.
+func (k *rsyncSSHKeys) ensureSecret(l logr.Logger, secret *corev1.Secret, keys map[string]string) (bool, error) {
 	logger := l.WithValues("secret", client.ObjectKeyFromObject(secret))
 
 	op, err := ctrlutil.CreateOrUpdate(k.Context, k.Client, secret, func() error {
@@ -250,8 +384,12 @@ func (k *rsyncSSHKeys) ensureSecret(l logr.Logger, secret *corev1.Secret, keys [
 		if secret.Data == nil {
 			secret.Data = make(map[string][]byte, 3)
 		}
-		for _, key := range keys {
-			secret.Data[key] = k.MainSecret.Data[key]
+		for key, mainKey := range keys {
+			if value, ok := k.MainSecret.Data[mainKey]; ok {
+				secret.Data[key] = value
+			} else {
+				delete(secret.Data, key)
+			}
 		}
 		return nil
 	})
@@ -265,10 +403,26 @@ func (k *rsyncSSHKeys) ensureSecret(l logr.Logger, secret *corev1.Secret, keys [
 
 func (k *rsyncSSHKeys) ensureSrcSecret(l logr.Logger) (bool, error) {
 	logger := l.WithValues("sourceSecret", client.ObjectKeyFromObject(k.SrcSecret))
-	return k.ensureSecret(logger, k.SrcSecret, []string{"source", "source.pub", "destination.pub"})
+	keys := map[string]string{
+		"source":          "source",
+		"source.pub":      "source.pub",
+		"destination.pub": "destination.pub",
+	}
+	if k.IsSource && k.hasPreviousKey() {
+		// The remote destination may not have received the new key yet, so keep
+		// using the previous key until the grace period ends
+		keys["source"] = "source.previous"
+		keys["source.pub"] = "source.pub.previous"
+	}
+	return k.ensureSecret(logger, k.SrcSecret, keys)
 }
 
 func (k *rsyncSSHKeys) ensureDestSecret(l logr.Logger) (bool, error) {
 	logger := l.WithValues("destSecret", client.ObjectKeyFromObject(k.DestSecret))
-	return k.ensureSecret(logger, k.DestSecret, []string{"destination", "destination.pub", "source.pub"})
+	return k.ensureSecret(logger, k.DestSecret, map[string]string{
+		"destination":         "destination",
+		"destination.pub":     "destination.pub",
+		"source.pub":          "source.pub",
+		"source.pub.previous": "source.pub.previous",
+	})
 }
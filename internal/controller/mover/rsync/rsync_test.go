@@ -498,6 +498,71 @@ var _ = Describe("Rsync as a source", func() {
 				})
 			})
 
+			When("key rotation is requested", func() {
+				BeforeEach(func() {
+					rs.Spec.Rsync = &volsyncv1alpha1.ReplicationSourceRsyncSpec{
+						KeyRotationToken: ptr.To("initial"),
+					}
+				})
+				It("Rotates the source key and keeps the previous key during the grace period", func() {
+					Eventually(func() *string {
+						keyName, err := mover.ensureSecrets(ctx)
+						if err != nil {
+							return nil
+						}
+						return keyName
+					}, maxWait, interval).Should(Not(BeNil()))
+					// The token present at key creation doesn't trigger a rotation
+					Expect(*rs.Status.Rsync.KeyRotationToken).To(Equal("initial"))
+					Expect(rs.Status.Rsync.LastKeyRotation).To(BeNil())
+
+					mainSecret := &corev1.Secret{}
+					mainKey := types.NamespacedName{Name: "volsync-rsync-src-main-" + rs.GetName(), Namespace: rs.Namespace}
+					Expect(k8sClient.Get(ctx, mainKey, mainSecret)).To(Succeed())
+					oldPub := mainSecret.Data["source.pub"]
+
+					mover.keyRotation.token = ptr.To("rotate")
+					keyName, err := mover.ensureSecrets(ctx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(keyName).ToNot(BeNil())
+					Expect(*rs.Status.Rsync.KeyRotationToken).To(Equal("rotate"))
+					Expect(rs.Status.Rsync.LastKeyRotation).ToNot(BeNil())
+					Expect(rs.Status.Rsync.PreviousKeyExpiration).ToNot(BeNil())
+
+					Expect(k8sClient.Get(ctx, mainKey, mainSecret)).To(Succeed())
+					Expect(mainSecret.Data["source.pub"]).NotTo(Equal(oldPub))
+					Expect(mainSecret.Data["source.pub.previous"]).To(Equal(oldPub))
+
+					// The exported dest secret accepts both keys
+					destSecret := &corev1.Secret{}
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: *rs.Status.Rsync.SSHKeys,
+						Namespace: rs.Namespace}, destSecret)).To(Succeed())
+					Expect(destSecret.Data["source.pub"]).To(Equal(mainSecret.Data["source.pub"]))
+					Expect(destSecret.Data["source.pub.previous"]).To(Equal(oldPub))
+
+					// The source keeps using the previous key during the grace period
+					srcSecret := &corev1.Secret{}
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: *keyName,
+						Namespace: rs.Namespace}, srcSecret)).To(Succeed())
+					Expect(srcSecret.Data["source.pub"]).To(Equal(oldPub))
+
+					// End the grace period
+					mainSecret.Annotations[previousKeyExpirationAnnotation] = "2000-01-01T00:00:00Z"
+					Expect(k8sClient.Update(ctx, mainSecret)).To(Succeed())
+					_, err = mover.ensureSecrets(ctx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(rs.Status.Rsync.PreviousKeyExpiration).To(BeNil())
+
+					Expect(k8sClient.Get(ctx, mainKey, mainSecret)).To(Succeed())
+					Expect(mainSecret.Data).NotTo(HaveKey("source.pub.previous"))
+					Expect(mainSecret.Data).NotTo(HaveKey("source.previous"))
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(destSecret), destSecret)).To(Succeed())
+					Expect(destSecret.Data).NotTo(HaveKey("source.pub.previous"))
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(srcSecret), srcSecret)).To(Succeed())
+					Expect(srcSecret.Data["source.pub"]).To(Equal(mainSecret.Data["source.pub"]))
+				})
+			})
+
 			//nolint:dupl
 			Context("When ssh keys are provided", func() {
 				Context("When provided secret exists with proper fields", func() {
@@ -831,6 +896,50 @@ var _ = Describe("Rsync as a source", func() {
 					Expect(foundTmpMount).To(BeTrue())
 				})
 
+				When("exclude patterns and an exclude ConfigMap are provided", func() {
+					BeforeEach(func() {
+						rs.Spec.Rsync.ExcludePatterns = []string{"cache/", "*.sock"}
+						rs.Spec.Rsync.ExcludeConfigMap = &volsyncv1alpha1.ConfigMapKeySpec{
+							ConfigMapName: "excludes",
+							Key:           "exclude.txt",
+						}
+					})
+					It("Should pass the patterns and mount the exclude file", func() {
+						j, e := mover.ensureJob(ctx, sPVC, sa, sshKeysSecret.GetName()) // Using sPVC as dataPVC (i.e. direct)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(j).To(BeNil()) // hasn't completed
+						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+						job = &batchv1.Job{}
+						Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+
+						c := job.Spec.Template.Spec.Containers[0]
+						Expect(c.Env).To(ContainElements(
+							corev1.EnvVar{Name: "RSYNC_EXCLUDE_PATTERNS", Value: "cache/\n*.sock"},
+							corev1.EnvVar{Name: "RSYNC_EXCLUDE_FROM", Value: "/rsync-excludes/excludes.txt"},
+						))
+						Expect(c.VolumeMounts).To(ContainElement(
+							corev1.VolumeMount{Name: "rsync-excludes", MountPath: "/rsync-excludes"}))
+
+						var excludeVolume *corev1.Volume
+						for i := range job.Spec.Template.Spec.Volumes {
+							if job.Spec.Template.Spec.Volumes[i].Name == "rsync-excludes" {
+								excludeVolume = &job.Spec.Template.Spec.Volumes[i]
+							}
+						}
+						Expect(excludeVolume).NotTo(BeNil())
+						Expect(excludeVolume.ConfigMap).NotTo(BeNil())
+						Expect(excludeVolume.ConfigMap.Name).To(Equal("excludes"))
+						Expect(excludeVolume.ConfigMap.Items).To(Equal([]corev1.KeyToPath{
+							{Key: "exclude.txt", Path: "excludes.txt"},
+						}))
+					})
+
+					It("Should fail validation if the ConfigMap is missing", func() {
+						Expect(utils.ValidateRsyncExcludes(ctx, k8sClient, logger, ns.Name,
+							mover.excludePatterns, mover.excludeConfigMap)).NotTo(Succeed())
+					})
+				})
+
 				getSPVC := func() *corev1.PersistentVolumeClaim {
 					return sPVC
 				}
@@ -0,0 +1,109 @@
+//go:build !disable_rsynctls
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsynctls
+
+import (
+	"context"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	"github.com/backube/volsync/internal/controller/mover"
+	"github.com/backube/volsync/internal/controller/volumehandler"
+)
+
+const (
+	blockTransferStateVolumeName = "block-transfer-state"
+	blockTransferStateMountPath  = "/state"
+)
+
+// The state volume only holds the checkpoint of a resumable transfer
+var blockTransferStateCapacity = resource.MustParse("64Mi")
+
+// blockTransferEnvVars returns the environment variables that configure
+// diskrsync-tcp on the source
+func (m *Mover) blockTransferEnvVars() []corev1.EnvVar {
+	if m.blockTransfer == nil {
+		return nil
+	}
+	env := []corev1.EnvVar{}
+	if ptr.Deref(m.blockTransfer.Resumable, false) {
+		env = append(env, corev1.EnvVar{Name: "DISKRSYNC_RESUME", Value: "1"})
+	}
+	if m.blockTransfer.Compression != nil {
+		env = append(env, corev1.EnvVar{Name: "DISKRSYNC_COMPRESSION", Value: *m.blockTransfer.Compression})
+	}
+	if m.blockTransfer.CompressionLevel != nil {
+		env = append(env, corev1.EnvVar{
+			Name: "DISKRSYNC_COMPRESSION_LEVEL", Value: strconv.Itoa(int(*m.blockTransfer.CompressionLevel)),
+		})
+	}
+	if m.blockTransfer.Streams != nil {
+		env = append(env, corev1.EnvVar{
+			Name: "DISKRSYNC_STREAMS", Value: strconv.Itoa(int(*m.blockTransfer.Streams)),
+		})
+	}
+	if m.blockTransfer.Checksum != nil {
+		env = append(env, corev1.EnvVar{Name: "DISKRSYNC_CHECKSUM", Value: *m.blockTransfer.Checksum})
+	}
+	return env
+}
+
+// ensureBlockTransferStatePVC allocates the volume that keeps the progress of
+// resumable transfers on the destination, so that a transfer continues where it
+// left off after the destination mover restarts
+func (m *Mover) ensureBlockTransferStatePVC(ctx context.Context,
+	dataPVC *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error) {
+	stateConfig := []volumehandler.VHOption{
+		volumehandler.From(m.vh),
+		// The state is a file, even though the data volume is a block device
+		volumehandler.VolumeMode(ptr.To(corev1.PersistentVolumeFilesystem)),
+		volumehandler.Capacity(&blockTransferStateCapacity),
+	}
+	if len(m.vh.GetAccessModes()) == 0 {
+		stateConfig = append(stateConfig, volumehandler.AccessModes(dataPVC.Spec.AccessModes))
+	}
+	stateVh, err := volumehandler.NewVolumeHandler(stateConfig...)
+	if err != nil {
+		return nil, err
+	}
+
+	stateName := mover.VolSyncPrefix + m.owner.GetName() + "-" + m.direction() + "-state"
+	return stateVh.EnsureNewPVC(ctx, m.logger, stateName, m.cleanupTempPVC)
+}
+
+// addBlockTransferStateToPodSpec mounts the volume that keeps the progress of
+// resumable transfers
+func (m *Mover) addBlockTransferStateToPodSpec(podSpec *corev1.PodSpec) {
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      blockTransferStateVolumeName,
+		MountPath: blockTransferStateMountPath,
+	})
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: blockTransferStateVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: *m.blockTransferStatePVC,
+			},
+		},
+	})
+}
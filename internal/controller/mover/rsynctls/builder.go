@@ -26,6 +26,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/spf13/viper"
 	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
@@ -124,6 +125,16 @@ func (rb *Builder) FromSource(client client.Client, logger logr.Logger,
 	saHandler := utils.NewSAHandler(client, source, isSource, privileged,
 		source.Spec.RsyncTLS.MoverServiceAccount)
 
+	// Changed blocks are found by comparing snapshots, and a single base
+	// snapshot can't serve several targets
+	blockTransfer := source.Spec.RsyncTLS.BlockTransfer
+	changedBlocks := blockTransfer != nil && ptr.Deref(blockTransfer.ChangedBlocks, false) &&
+		source.Spec.RsyncTLS.CopyMethod == volsyncv1alpha1.CopyMethodSnapshot &&
+		len(source.Spec.RsyncTLS.Targets) == 0
+	if volsyncSA, ok := saHandler.(*utils.SAHandlerVolSync); ok && changedBlocks {
+		volsyncSA.AdditionalRules = changedBlocksMoverRules
+	}
+
 	return &Mover{
 		client:             client,
 		logger:             logger.WithValues("method", "RsyncTLS"),
@@ -133,6 +144,8 @@ func (rb *Builder) FromSource(client client.Client, logger logr.Logger,
 		saHandler:          saHandler,
 		containerImage:     rb.getRsyncTLSContainerImage(),
 		key:                source.Spec.RsyncTLS.KeySecret,
+		pskIdentity:        source.Spec.RsyncTLS.PSKIdentity,
+		certificate:        source.Spec.RsyncTLS.Certificate,
 		serviceType:        nil,
 		serviceAnnotations: nil,
 		address:            source.Spec.RsyncTLS.Address,
@@ -145,6 +158,12 @@ func (rb *Builder) FromSource(client client.Client, logger logr.Logger,
 		latestMoverStatus:  source.Status.LatestMoverStatus,
 		moverConfig:        source.Spec.RsyncTLS.MoverConfig,
 		moverVolumes:       source.Spec.RsyncTLS.MoverVolumes,
+		tuning:             source.Spec.RsyncTLS.Tuning,
+		excludePatterns:    source.Spec.RsyncTLS.ExcludePatterns,
+		excludeConfigMap:   source.Spec.RsyncTLS.ExcludeConfigMap,
+		targets:            source.Spec.RsyncTLS.Targets,
+		blockTransfer:      blockTransfer,
+		changedBlocks:      changedBlocks,
 	}, nil
 }
 
@@ -199,6 +218,7 @@ func (rb *Builder) FromDestination(client client.Client, logger logr.Logger,
 		saHandler:          saHandler,
 		containerImage:     rb.getRsyncTLSContainerImage(),
 		key:                destination.Spec.RsyncTLS.KeySecret,
+		certificate:        destination.Spec.RsyncTLS.Certificate,
 		serviceType:        destination.Spec.RsyncTLS.ServiceType,
 		serviceAnnotations: svcAnnotations,
 		address:            nil,
@@ -212,5 +232,8 @@ func (rb *Builder) FromDestination(client client.Client, logger logr.Logger,
 		latestMoverStatus:  destination.Status.LatestMoverStatus,
 		moverConfig:        destination.Spec.RsyncTLS.MoverConfig,
 		moverVolumes:       destination.Spec.RsyncTLS.MoverVolumes,
+		persistent:         ptr.Deref(destination.Spec.RsyncTLS.Persistent, false),
+		route:              destination.Spec.RsyncTLS.Route,
+		tlsRoute:           destination.Spec.RsyncTLS.TLSRoute,
 	}, nil
 }
@@ -0,0 +1,264 @@
+//go:build !disable_rsynctls
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsynctls
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/backube/volsync/internal/controller/mover"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+const (
+	snapshotMetadataTokenVolumeName = "snapshot-metadata-token"
+	snapshotMetadataTokenMountPath  = "/var/run/secrets/snapshot-metadata"
+	snapshotMetadataTokenPath       = "token"
+	// Lifetime of the token the mover uses to authenticate with the
+	// SnapshotMetadata service
+	snapshotMetadataTokenExpiration = int64(3600)
+	// Suffix of the name of every other source snapshot, so that the snapshot
+	// of the previous synchronization is kept while the next one is taken
+	alternateSnapshotSuffix = "-b"
+)
+
+// The SnapshotMetadataService of a CSI driver is cluster-scoped and named
+// after the driver. It is read as unstructured so that VolSync doesn't depend
+// on the external-snapshot-metadata API and works with either version.
+var snapshotMetadataServiceGVKs = []schema.GroupVersionKind{
+	{Group: "cbt.storage.k8s.io", Version: "v1beta1", Kind: "SnapshotMetadataService"},
+	{Group: "cbt.storage.k8s.io", Version: "v1alpha1", Kind: "SnapshotMetadataService"},
+}
+
+// changedBlocksMoverRules are granted to the source mover when changed blocks
+// are enabled. The SnapshotMetadata service only answers requests from
+// ServiceAccounts that are allowed to get the snapshots.
+var changedBlocksMoverRules = []rbacv1.PolicyRule{
+	{
+		APIGroups: []string{snapv1.GroupName},
+		Resources: []string{"volumesnapshots"},
+		Verbs:     []string{"get", "list"},
+	},
+}
+
+// changedBlocksConfig tells the mover how to query the blocks that changed
+// between the base snapshot and the snapshot being transferred
+type changedBlocksConfig struct {
+	address        string
+	caCert         string
+	audience       string
+	baseSnapshotID string
+	targetSnapshot string
+}
+
+// sourceDataName returns the name of the snapshot and PVC of the current
+// synchronization, which differs from the name of the base snapshot kept from
+// the previous one
+func (m *Mover) sourceDataName() string {
+	name := mover.VolSyncPrefix + m.owner.GetName() + "-" + m.direction()
+	if m.sourceStatus.BaseSnapshot != nil && *m.sourceStatus.BaseSnapshot == name {
+		return name + alternateSnapshotSuffix
+	}
+	return name
+}
+
+// getChangedBlocksConfig returns the configuration to transfer only the blocks
+// that changed since the base snapshot, or nil if the whole volume has to be
+// transferred
+func (m *Mover) getChangedBlocksConfig(ctx context.Context) (*changedBlocksConfig, error) {
+	if !m.changedBlocks || m.sourceStatus.BaseSnapshot == nil {
+		return nil, nil
+	}
+	base := &snapv1.VolumeSnapshot{}
+	err := m.client.Get(ctx, client.ObjectKey{Name: *m.sourceStatus.BaseSnapshot, Namespace: m.owner.GetNamespace()},
+		base)
+	if kerrors.IsNotFound(err) {
+		// The status is cleared once the synchronization completes, as the
+		// name of the current snapshot depends on it
+		m.logger.Info("base snapshot not found, transferring the whole volume",
+			"snapshot", *m.sourceStatus.BaseSnapshot)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	content, service, err := m.getSnapshotMetadataService(ctx, base)
+	if content == nil || service == nil || err != nil {
+		return nil, err
+	}
+	if content.Status == nil || content.Status.SnapshotHandle == nil {
+		return nil, fmt.Errorf("%s has no snapshot handle",
+			utils.KindAndName(m.client.Scheme(), content))
+	}
+
+	address, _, _ := unstructured.NestedString(service.Object, "spec", "address")
+	audience, _, _ := unstructured.NestedString(service.Object, "spec", "audience")
+	encodedCACert, _, _ := unstructured.NestedString(service.Object, "spec", "caCert")
+	caCert, err := base64.StdEncoding.DecodeString(encodedCACert)
+	if err != nil {
+		return nil, fmt.Errorf("invalid caCert in SnapshotMetadataService %s: %w", service.GetName(), err)
+	}
+	if address == "" {
+		return nil, fmt.Errorf("SnapshotMetadataService %s has no address", service.GetName())
+	}
+
+	return &changedBlocksConfig{
+		address:        address,
+		caCert:         string(caCert),
+		audience:       audience,
+		baseSnapshotID: *content.Status.SnapshotHandle,
+		targetSnapshot: m.sourceDataName(),
+	}, nil
+}
+
+// getSnapshotMetadataService returns the VolumeSnapshotContent of the snapshot
+// and the SnapshotMetadataService of its CSI driver, or nil if the driver
+// doesn't provide one
+func (m *Mover) getSnapshotMetadataService(ctx context.Context,
+	snap *snapv1.VolumeSnapshot) (*snapv1.VolumeSnapshotContent, *unstructured.Unstructured, error) {
+	if snap.Status == nil || snap.Status.BoundVolumeSnapshotContentName == nil {
+		return nil, nil, nil
+	}
+	content := &snapv1.VolumeSnapshotContent{}
+	if err := m.client.Get(ctx, client.ObjectKey{Name: *snap.Status.BoundVolumeSnapshotContentName},
+		content); err != nil {
+		return nil, nil, err
+	}
+
+	for _, gvk := range snapshotMetadataServiceGVKs {
+		service := &unstructured.Unstructured{}
+		service.SetGroupVersionKind(gvk)
+		err := m.client.Get(ctx, client.ObjectKey{Name: content.Spec.Driver}, service)
+		if err == nil {
+			return content, service, nil
+		}
+		if !kerrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return nil, nil, err
+		}
+	}
+	m.logger.V(1).Info("no SnapshotMetadataService for the CSI driver", "driver", content.Spec.Driver)
+	return content, nil, nil
+}
+
+// updateBaseSnapshot keeps the snapshot of the completed synchronization as
+// the base of the next one, and deletes the previous base snapshot
+func (m *Mover) updateBaseSnapshot(ctx context.Context, dataPVC *corev1.PersistentVolumeClaim) error {
+	if !m.changedBlocks || !utils.PvcIsBlockMode(dataPVC) {
+		return m.deleteBaseSnapshot(ctx)
+	}
+
+	snap := &snapv1.VolumeSnapshot{}
+	if err := m.client.Get(ctx, client.ObjectKey{Name: m.sourceDataName(), Namespace: m.owner.GetNamespace()},
+		snap); err != nil {
+		return err
+	}
+	_, service, err := m.getSnapshotMetadataService(ctx, snap)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		// Keeping the snapshot is of no use without the service
+		return m.deleteBaseSnapshot(ctx)
+	}
+
+	// Keep the snapshot, it is still deleted along with the owner
+	if utils.UnmarkForCleanup(snap) {
+		if err := m.client.Update(ctx, snap); err != nil {
+			m.logger.Error(err, "unable to keep base snapshot", "snapshot", client.ObjectKeyFromObject(snap))
+			return err
+		}
+	}
+	if err := m.deleteBaseSnapshot(ctx); err != nil {
+		return err
+	}
+	m.sourceStatus.BaseSnapshot = ptr.To(snap.GetName())
+	m.logger.V(1).Info("base snapshot updated", "snapshot", client.ObjectKeyFromObject(snap))
+	return nil
+}
+
+// deleteBaseSnapshot deletes the base snapshot kept from the previous
+// synchronization
+func (m *Mover) deleteBaseSnapshot(ctx context.Context) error {
+	if m.sourceStatus.BaseSnapshot == nil {
+		return nil
+	}
+	snap := &snapv1.VolumeSnapshot{}
+	err := m.client.Get(ctx, client.ObjectKey{Name: *m.sourceStatus.BaseSnapshot, Namespace: m.owner.GetNamespace()},
+		snap)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && metav1.IsControlledBy(snap, m.owner) {
+		if err := m.client.Delete(ctx, snap); client.IgnoreNotFound(err) != nil {
+			m.logger.Error(err, "unable to delete base snapshot", "snapshot", client.ObjectKeyFromObject(snap))
+			return err
+		}
+	}
+	m.sourceStatus.BaseSnapshot = nil
+	return nil
+}
+
+// addChangedBlocksToPodSpec configures the mover to query the changed blocks,
+// authenticating with a token of its ServiceAccount for the audience of the
+// service
+func (m *Mover) addChangedBlocksToPodSpec(podSpec *corev1.PodSpec) {
+	config := m.changedBlocksConfig
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env,
+		corev1.EnvVar{Name: "SNAPSHOT_METADATA_ADDRESS", Value: config.address},
+		corev1.EnvVar{Name: "SNAPSHOT_METADATA_CA_CERT", Value: config.caCert},
+		corev1.EnvVar{Name: "SNAPSHOT_METADATA_TOKEN_FILE",
+			Value: snapshotMetadataTokenMountPath + "/" + snapshotMetadataTokenPath},
+		corev1.EnvVar{Name: "SNAPSHOT_NAMESPACE", Value: m.owner.GetNamespace()},
+		corev1.EnvVar{Name: "BASE_SNAPSHOT_ID", Value: config.baseSnapshotID},
+		corev1.EnvVar{Name: "TARGET_SNAPSHOT_NAME", Value: config.targetSnapshot},
+	)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      snapshotMetadataTokenVolumeName,
+		MountPath: snapshotMetadataTokenMountPath,
+		ReadOnly:  true,
+	})
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: snapshotMetadataTokenVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          config.audience,
+						ExpirationSeconds: ptr.To(snapshotMetadataTokenExpiration),
+						Path:              snapshotMetadataTokenPath,
+					},
+				}},
+			},
+		},
+	})
+}
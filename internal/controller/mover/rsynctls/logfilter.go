@@ -26,7 +26,19 @@ import (
 var rsyncTLSRegex = regexp.MustCompile(
 	`([sS]ent)\s.+([bB]ytes)\s.+([rR]eceived)\s.+([bB]ytes)|` +
 		`([tT]otal size)|` +
-		`([rR]sync completed in)`)
+		`([rR]sync completed in)|` +
+		`(PSK identity used:)|` +
+		`(Transfer \d+-[0-9a-f]+ completed)|` +
+		`(Resumed transfer)|` +
+		`(Changed blocks transferred)|` +
+		`(Sync summary)|` +
+		`(checksums of the source and target devices differ)`)
+
+// The pre-shared key identity reported by the mover, recorded in the status
+var pskIdentityRegex = regexp.MustCompile(`PSK identity used: (\S+)`)
+
+// Reported by the persistent destination mover after each transfer
+var transferCompletedRegex = regexp.MustCompile(`Transfer (\d+-[0-9a-f]+) completed`)
 
 var rsyncTLSRegexFailures = regexp.MustCompile(
 	`^\s*([rR]sync)|` +
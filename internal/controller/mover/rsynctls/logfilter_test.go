@@ -762,4 +762,96 @@ Shutting down...
 			Expect(filteredLines).To(Equal(expectedFilteredLog))
 		})
 	})
+
+	Context("RsyncTLS mover logs with the PSK identity", func() {
+		identityLog := `2023.01.25 19:06:29 LOG6[ui]: PSK identities: 2 retrieved
+sent 1,003 bytes  received 5,657 bytes  13,320.00 bytes/sec
+total size is 1,162,761,244  speedup is 174,588.78
+PSK identity used: volsync-2
+rsync completed in 41s
+Sending shutdown to remote...`
+
+		expectedFilteredLog := `sent 1,003 bytes  received 5,657 bytes  13,320.00 bytes/sec
+total size is 1,162,761,244  speedup is 174,588.78
+PSK identity used: volsync-2
+rsync completed in 41s`
+
+		It("Should keep the identity in the filtered logs", func() {
+			reader := strings.NewReader(identityLog)
+			filteredLines, err := utils.FilterLogs(reader, rsynctls.LogLineFilterSuccess)
+			Expect(err).NotTo(HaveOccurred())
+
+			logger.Info("Filtered lines are", "filteredLines", filteredLines)
+			Expect(filteredLines).To(Equal(expectedFilteredLog))
+		})
+	})
+
+	Context("RsyncTLS block mover logs of a resumed transfer", func() {
+		resumedLog := `2026-10-19T05:37:07.213Z	INFO	diskrsync-tls (for VolSync) Version: 0.15.0
+2026-10-19T05:37:07.213Z	INFO	Opened filed	{"file": "/dev/block"}
+2026-10-19T05:37:07.214Z	INFO	Verifying data already transferred	{"offset": 8589934592}
+2026-10-19T05:37:41.402Z	INFO	Resumed transfer	{"skipped bytes": 8589934592, "total bytes": 10737418240}
+2026-10-19T05:37:41.402Z	INFO	Transferring segment	{"offset": 8589934592, "size": 1073741824}
+2026-10-19T05:37:51.118Z	INFO	Successfully completed sync
+diskrsync completed in 48s`
+
+		expectedFilteredLog := `2026-10-19T05:37:41.402Z	INFO	Resumed transfer	{"skipped bytes": 8589934592, "total bytes": 10737418240}
+diskrsync completed in 48s`
+
+		It("Should report how much was skipped", func() {
+			reader := strings.NewReader(resumedLog)
+			filteredLines, err := utils.FilterLogs(reader, rsynctls.LogLineFilterSuccess)
+			Expect(err).NotTo(HaveOccurred())
+
+			logger.Info("Filtered lines are", "filteredLines", filteredLines)
+			Expect(filteredLines).To(Equal(expectedFilteredLog))
+		})
+	})
+
+	Context("RsyncTLS block mover logs of a changed blocks transfer", func() {
+		changedBlocksLog := `2026-10-19T06:12:03.541Z	INFO	diskrsync-tls (for VolSync) Version: 0.15.0
+2026-10-19T06:12:03.541Z	INFO	Opened filed	{"file": "/dev/block"}
+2026-10-19T06:12:03.902Z	INFO	Found changed blocks	{"ranges": 37, "bytes": 157286400, "base snapshot": "snap-1", "target snapshot": "volsync-app-src-b"}
+2026-10-19T06:12:03.905Z	INFO	source	{"size": 10737418240}
+2026-10-19T06:12:04.906Z	INFO	sync progress 61.33%
+2026-10-19T06:12:05.377Z	INFO	Changed blocks transferred	{"bytes": 157286400, "total bytes": 10737418240}
+2026-10-19T06:12:05.377Z	INFO	Successfully completed sync
+diskrsync completed in 2s`
+
+		expectedFilteredLog := `2026-10-19T06:12:05.377Z	INFO	Changed blocks transferred	{"bytes": 157286400, "total bytes": 10737418240}
+diskrsync completed in 2s`
+
+		It("Should report how much was transferred", func() {
+			reader := strings.NewReader(changedBlocksLog)
+			filteredLines, err := utils.FilterLogs(reader, rsynctls.LogLineFilterSuccess)
+			Expect(err).NotTo(HaveOccurred())
+
+			logger.Info("Filtered lines are", "filteredLines", filteredLines)
+			Expect(filteredLines).To(Equal(expectedFilteredLog))
+		})
+	})
+
+	Context("RsyncTLS block mover logs with checksums", func() {
+		checksumLog := `2026-10-19T06:40:11.102Z	INFO	diskrsync-tls (for VolSync) Version: 0.15.0
+2026-10-19T06:40:11.102Z	INFO	Opened filed	{"file": "/dev/block"}
+2026-10-19T06:40:11.103Z	INFO	source	{"size": 10737418240}
+2026-10-19T06:40:52.610Z	INFO	Computing the device checksum
+2026-10-19T06:41:20.871Z	INFO	Sync summary	{"summary": {"bytesRead":10737418240,"bytesSent":283115520,"blocksChanged":2048,"durationSeconds":69.77,"sourceSHA256":"5f70bf18a086007016e948b04aed3b82103a36bea41755b6cddfaf10ace3c6ef","targetSHA256":"d2a84f4b8b650937ec8f73cd8be2c74add5a911ba64df27458ed8229da804a26"}}
+2026-10-19T06:41:20.871Z	INFO	The checksums of the source and target devices differ
+2026-10-19T06:41:20.871Z	INFO	Successfully completed sync
+diskrsync completed in 70s`
+
+		expectedFilteredLog := `2026-10-19T06:41:20.871Z	INFO	Sync summary	{"summary": {"bytesRead":10737418240,"bytesSent":283115520,"blocksChanged":2048,"durationSeconds":69.77,"sourceSHA256":"5f70bf18a086007016e948b04aed3b82103a36bea41755b6cddfaf10ace3c6ef","targetSHA256":"d2a84f4b8b650937ec8f73cd8be2c74add5a911ba64df27458ed8229da804a26"}}
+2026-10-19T06:41:20.871Z	INFO	The checksums of the source and target devices differ
+diskrsync completed in 70s`
+
+		It("Should report the summary of the sync", func() {
+			reader := strings.NewReader(checksumLog)
+			filteredLines, err := utils.FilterLogs(reader, rsynctls.LogLineFilterSuccess)
+			Expect(err).NotTo(HaveOccurred())
+
+			logger.Info("Filtered lines are", "filteredLines", filteredLines)
+			Expect(filteredLines).To(Equal(expectedFilteredLog))
+		})
+	})
 })
@@ -24,7 +24,12 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"net"
+	"path"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -53,6 +58,12 @@ const (
 	dataVolumeName   = "data"
 	tlsContainerPort = 8000
 
+	// Certificate-based TLS
+	tlsKeysMountPath = "/keys"
+	tlsCAVolumeName  = "tls-ca"
+	tlsCAMountPath   = "/tls-ca"
+	tlsCAFilename    = "ca.crt"
+
 	volSyncRsyncTLSPrefix = mover.VolSyncPrefix + "rsync-tls-"
 )
 
@@ -66,6 +77,8 @@ type Mover struct {
 	saHandler          utils.SAHandler
 	containerImage     string
 	key                *string
+	pskIdentity        *string
+	certificate        *volsyncv1alpha1.RsyncTLSCertificateSpec
 	serviceType        *corev1.ServiceType
 	serviceAnnotations map[string]string
 	address            *string
@@ -78,10 +91,27 @@ type Mover struct {
 	moverConfig        volsyncv1alpha1.MoverConfig
 	moverVolumes       []volsyncv1alpha1.MoverVolume
 	// Source-only fields
-	sourceStatus *volsyncv1alpha1.ReplicationSourceRsyncTLSStatus
+	sourceStatus     *volsyncv1alpha1.ReplicationSourceRsyncTLSStatus
+	tuning           *volsyncv1alpha1.RsyncTuningSpec
+	excludePatterns  []string
+	excludeConfigMap *volsyncv1alpha1.ConfigMapKeySpec
+	targets          []volsyncv1alpha1.RsyncTLSTargetSpec
+	blockTransfer    *volsyncv1alpha1.RsyncTLSBlockTransferSpec
+	// Set when only the blocks changed since the previous snapshot are sent
+	changedBlocks       bool
+	changedBlocksConfig *changedBlocksConfig
+	// Set when the Mover transfers to one of several targets
+	targetName   string
+	targetStatus *volsyncv1alpha1.RsyncTLSTargetStatus
 	// Destination-only fields
 	destStatus     *volsyncv1alpha1.ReplicationDestinationRsyncTLSStatus
 	cleanupTempPVC bool
+	persistent     bool
+	route          *volsyncv1alpha1.RsyncTLSRouteSpec
+	tlsRoute       *volsyncv1alpha1.RsyncTLSGatewayRouteSpec
+	// Set when a block volume is received, keeps the progress of resumable
+	// transfers
+	blockTransferStatePVC *string
 }
 
 var _ mover.Mover = &Mover{}
@@ -99,9 +129,19 @@ func (m *Mover) Name() string { return rsyncTLSMoverName }
 func (m *Mover) Synchronize(ctx context.Context) (mover.Result, error) {
 	var err error
 
+	if err := m.validateRoutes(); err != nil {
+		return mover.InProgress(), err
+	}
+
 	// Allocate temporary data PVC
 	var dataPVC *corev1.PersistentVolumeClaim
 	if m.isSource {
+		// The base snapshot is only kept while changed blocks are enabled
+		if !m.changedBlocks {
+			if err := m.deleteBaseSnapshot(ctx); err != nil {
+				return mover.InProgress(), err
+			}
+		}
 		dataPVC, err = m.ensureSourcePVC(ctx)
 	} else {
 		dataPVC, err = m.ensureDestinationPVC(ctx)
@@ -110,6 +150,22 @@ func (m *Mover) Synchronize(ctx context.Context) (mover.Result, error) {
 		return mover.InProgress(), err
 	}
 
+	if m.isSource {
+		if err := utils.ValidateRsyncExcludes(ctx, m.client, m.logger, m.owner.GetNamespace(),
+			m.excludePatterns, m.excludeConfigMap); err != nil {
+			return mover.InProgress(), err
+		}
+		if len(m.targets) > 0 {
+			return m.synchronizeTargets(ctx, dataPVC)
+		}
+		m.sourceStatus.Targets = nil
+
+		m.changedBlocksConfig, err = m.getChangedBlocksConfig(ctx)
+		if err != nil {
+			return mover.InProgress(), err
+		}
+	}
+
 	// Ensure service (if required) and publish the address in the status
 	cont, err := m.ensureServiceAndPublishAddress(ctx)
 	if !cont || err != nil {
@@ -134,6 +190,23 @@ func (m *Mover) Synchronize(ctx context.Context) (mover.Result, error) {
 		return mover.InProgress(), err
 	}
 
+	if !m.isSource {
+		if utils.PvcIsBlockMode(dataPVC) {
+			statePVC, err := m.ensureBlockTransferStatePVC(ctx, dataPVC)
+			if statePVC == nil || err != nil {
+				return mover.InProgress(), err
+			}
+			m.blockTransferStatePVC = &statePVC.Name
+		}
+		if m.persistent {
+			return m.synchronizePersistent(ctx, dataPVC, sa, *rsyncPSKSecretName)
+		}
+		// Remove the Deployment if the destination is no longer persistent
+		if err := m.deletePersistentDeployment(ctx); err != nil {
+			return mover.InProgress(), err
+		}
+	}
+
 	// Ensure mover Job
 	job, err := m.ensureJob(ctx, dataPVC, sa, *rsyncPSKSecretName)
 	if job == nil || err != nil {
@@ -149,7 +222,11 @@ func (m *Mover) Synchronize(ctx context.Context) (mover.Result, error) {
 		return mover.CompleteWithImage(image), nil
 	}
 
-	// On the source, just signal completion
+	// On the source, keep the snapshot for the next synchronization if
+	// required and signal completion
+	if err := m.updateBaseSnapshot(ctx, dataPVC); err != nil {
+		return mover.InProgress(), err
+	}
 	return mover.Complete(), nil
 }
 
@@ -180,6 +257,18 @@ func (m *Mover) ensureServiceAndPublishAddress(ctx context.Context) (bool, error
 		return false, err
 	}
 
+	if err := m.removeUnusedRoutes(ctx, service); err != nil {
+		return false, err
+	}
+
+	switch {
+	case m.route != nil:
+		return m.ensureRouteAndPublishAddress(ctx, service)
+	case m.tlsRoute != nil:
+		return m.ensureTLSRouteAndPublishAddress(ctx, service)
+	}
+
+	m.destStatus.Port = nil
 	return m.publishSvcAddress(service)
 }
 
@@ -229,6 +318,11 @@ func (m *Mover) updateStatusPSK(pskSecretName *string) {
 // Will ensure the secret exists or create secrets if necessary
 // - Returns the name of the secret that should be used in the replication job
 func (m *Mover) ensureSecrets(ctx context.Context) (*string, error) {
+	// Certificate-based TLS uses the user provided certificate Secret
+	if m.certificate != nil {
+		return m.ensureCertificateSecret(ctx)
+	}
+
 	// If user provided key, use that
 	if m.key != nil {
 		keySecret := &corev1.Secret{
@@ -242,6 +336,9 @@ func (m *Mover) ensureSecrets(ctx context.Context) (*string, error) {
 			m.logger.Error(err, "Key Secret does not contain the proper fields")
 			return nil, err
 		}
+		if err := m.validatePSKIdentities(keySecret); err != nil {
+			return nil, err
+		}
 		return m.key, nil
 	}
 
@@ -258,6 +355,13 @@ func (m *Mover) ensureSecrets(ctx context.Context) (*string, error) {
 		return nil, err
 	}
 
+	if err == nil {
+		// The generated key Secret may have been edited to add identities
+		if err := m.validatePSKIdentities(keySecret); err != nil {
+			return nil, err
+		}
+	}
+
 	if kerrors.IsNotFound(err) {
 		keyData := make([]byte, 64)
 		if _, err := rand.Read(keyData); err != nil {
@@ -283,6 +387,108 @@ func (m *Mover) ensureSecrets(ctx context.Context) (*string, error) {
 	return &keySecret.Name, nil
 }
 
+// ensureCertificateSecret validates the Secret holding the certificate for
+// mutual TLS and returns its name
+func (m *Mover) ensureCertificateSecret(ctx context.Context) (*string, error) {
+	if m.key != nil {
+		err := errors.New("keySecret and certificate can not be used together")
+		m.logger.Error(err, "RsyncTLS Spec validation error")
+		return nil, err
+	}
+	if len(m.certificate.SecretName) == 0 {
+		err := errors.New("certificate requires a secretName")
+		m.logger.Error(err, "RsyncTLS Spec validation error")
+		return nil, err
+	}
+
+	certSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.certificate.SecretName,
+			Namespace: m.owner.GetNamespace(),
+		},
+	}
+	fields := []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey}
+	if m.certificate.CABundle == nil {
+		fields = append(fields, corev1.ServiceAccountRootCAKey)
+	}
+	if err := utils.GetAndValidateSecret(ctx, m.client, m.logger, certSecret, fields...); err != nil {
+		m.logger.Error(err, "Certificate Secret does not contain the proper fields")
+		return nil, err
+	}
+
+	// No pre-shared key is used
+	m.updateStatusPSK(nil)
+	return &certSecret.Name, nil
+}
+
+// validatePSKIdentities checks the identities in the pre-shared key file. The
+// file may hold several "identity:key" lines so that a new key can be added
+// on the destination before the source switches to it.
+func (m *Mover) validatePSKIdentities(keySecret *corev1.Secret) error {
+	identities, err := parsePSKIdentities(keySecret.Data["psk.txt"])
+	if err == nil && m.isSource && m.pskIdentity != nil && !slices.Contains(identities, *m.pskIdentity) {
+		err = fmt.Errorf("pskIdentity %q not found in key Secret", *m.pskIdentity)
+	}
+	if err != nil {
+		m.logger.Error(err, "Key Secret validation error",
+			"keySecret", client.ObjectKeyFromObject(keySecret))
+	}
+	return err
+}
+
+// parsePSKIdentities returns the identities in a stunnel PSKsecrets file, in
+// the order they appear
+func parsePSKIdentities(pskFile []byte) ([]string, error) {
+	identities := []string{}
+	for _, line := range strings.Split(string(pskFile), "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		identity, key, found := strings.Cut(line, ":")
+		if !found || len(identity) == 0 {
+			return nil, errors.New("psk.txt lines must be in the format <identity>:<key>")
+		}
+		// stunnel requires keys of at least 16 bytes
+		if len(key) < 16 {
+			return nil, fmt.Errorf("key for PSK identity %q is too short", identity)
+		}
+		if slices.Contains(identities, identity) {
+			return nil, fmt.Errorf("duplicate PSK identity %q", identity)
+		}
+		identities = append(identities, identity)
+	}
+	if len(identities) == 0 {
+		return nil, errors.New("psk.txt does not contain any key")
+	}
+	return identities, nil
+}
+
+// updateStatusLastPSKIdentity records the identity reported by the mover in
+// its logs
+func (m *Mover) updateStatusLastPSKIdentity() {
+	match := pskIdentityRegex.FindStringSubmatch(m.latestMoverStatus.Logs)
+	if match == nil {
+		return
+	}
+	identity := match[1]
+	if m.targetStatus != nil {
+		m.targetStatus.LastPSKIdentity = &identity
+	} else if m.isSource {
+		m.sourceStatus.LastPSKIdentity = &identity
+	} else {
+		m.destStatus.LastPSKIdentity = &identity
+	}
+}
+
+func (m *Mover) jobNamePrefix() string {
+	prefix := volSyncRsyncTLSPrefix + m.direction() + "-"
+	if m.targetName != "" {
+		prefix += m.targetName + "-"
+	}
+	return prefix
+}
+
 func (m *Mover) direction() string {
 	dir := "src"
 	if !m.isSource {
@@ -331,8 +537,7 @@ func (m *Mover) ensureSourcePVC(ctx context.Context) (*corev1.PersistentVolumeCl
 		m.logger.Error(err, "unable to get source PVC", "PVC", client.ObjectKeyFromObject(srcPVC))
 		return nil, err
 	}
-	dataName := mover.VolSyncPrefix + m.owner.GetName() + "-" + m.direction()
-	pvc, err := m.vh.EnsurePVCFromSrc(ctx, m.logger, srcPVC, dataName, true)
+	pvc, err := m.vh.EnsurePVCFromSrc(ctx, m.logger, srcPVC, m.sourceDataName(), true)
 	if err != nil {
 		// If the error was a copy TriggerTimeoutError, update the latestMoverStatus to indicate error
 		var copyTriggerTimeoutError *vserrors.CopyTriggerTimeoutError
@@ -369,12 +574,17 @@ func (m *Mover) ensureJob(ctx context.Context, dataPVC *corev1.PersistentVolumeC
 	sa *corev1.ServiceAccount, rsyncSecretName string) (*batchv1.Job, error) {
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      utils.GetJobName(volSyncRsyncTLSPrefix+m.direction()+"-", m.owner),
+			Name:      utils.GetJobName(m.jobNamePrefix(), m.owner),
 			Namespace: m.owner.GetNamespace(),
 		},
 	}
 	logger := m.logger.WithValues("job", client.ObjectKeyFromObject(job))
 
+	tlsCAObj, err := m.validateTLSCA(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	op, err := utils.CreateOrUpdateDeleteOnImmutableErr(ctx, m.client, job, logger, func() error {
 		if err := ctrl.SetControllerReference(m.owner, job, m.client.Scheme()); err != nil {
 			logger.Error(err, utils.ErrUnableToSetControllerRef)
@@ -395,133 +605,11 @@ func (m *Mover) ensureJob(ctx context.Context, dataPVC *corev1.PersistentVolumeC
 		}
 		job.Spec.Parallelism = &parallelism
 
-		readOnlyVolume := false
-		blockVolume := utils.PvcIsBlockMode(dataPVC)
-
-		containerEnv := []corev1.EnvVar{}
-		containerCmd := []string{"/bin/bash", "-c", "/mover-rsync-tls/server.sh"} // cmd for replicationDestination job
-		if m.isSource {
-			// Set dest address/port if necessary
-			if m.address != nil {
-				containerEnv = append(containerEnv, corev1.EnvVar{Name: "DESTINATION_ADDRESS", Value: *m.address})
-			}
-			if m.port != nil {
-				connectPort := strconv.Itoa(int(*m.port))
-				containerEnv = append(containerEnv, corev1.EnvVar{Name: "DESTINATION_PORT", Value: connectPort})
-			}
-			// Set container cmd for the replicationSource job
-			containerCmd = []string{"/bin/bash", "-c", "/mover-rsync-tls/client.sh"}
-
-			// Set read-only for volume in repl source job spec if the PVC only supports read-only
-			readOnlyVolume = utils.PvcIsReadOnly(dataPVC)
-		}
-		podSpec := &job.Spec.Template.Spec
-		podSpec.Containers = []corev1.Container{{
-			Name:    "rsync-tls",
-			Env:     containerEnv,
-			Command: containerCmd,
-			Image:   m.containerImage,
-			SecurityContext: &corev1.SecurityContext{
-				AllowPrivilegeEscalation: ptr.To(false),
-				Capabilities: &corev1.Capabilities{
-					Drop: []corev1.Capability{"ALL"},
-				},
-				Privileged:             ptr.To(false),
-				ReadOnlyRootFilesystem: ptr.To(true),
-			},
-		}}
-		volumeMounts := []corev1.VolumeMount{}
-		if !blockVolume {
-			volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: dataVolumeName, MountPath: mountPath})
-		}
-		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: "keys", MountPath: "/keys"},
-			corev1.VolumeMount{Name: "tempdir", MountPath: "/tmp"})
-		job.Spec.Template.Spec.Containers[0].VolumeMounts = volumeMounts
-		if blockVolume {
-			job.Spec.Template.Spec.Containers[0].VolumeDevices = []corev1.VolumeDevice{
-				{Name: dataVolumeName, DevicePath: devicePath},
-			}
-		}
-		podSpec.RestartPolicy = corev1.RestartPolicyNever
-		podSpec.ServiceAccountName = sa.Name
-		podSpec.Volumes = []corev1.Volume{
-			{Name: dataVolumeName, VolumeSource: corev1.VolumeSource{
-				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-					ClaimName: dataPVC.Name,
-					ReadOnly:  readOnlyVolume,
-				}},
-			},
-			{Name: "keys", VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName:  rsyncSecretName,
-					DefaultMode: ptr.To[int32](0600),
-				}},
-			},
-			{Name: "tempdir", VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{
-					Medium: corev1.StorageMediumMemory,
-				}},
-			},
-		}
-		if m.vh.IsCopyMethodDirect() {
-			affinity, err := utils.AffinityFromVolume(ctx, m.client, logger, dataPVC)
-			if err != nil {
-				logger.Error(err, "unable to determine proper affinity", "PVC", client.ObjectKeyFromObject(dataPVC))
-				return err
-			}
-			podSpec.NodeSelector = affinity.NodeSelector
-			podSpec.Tolerations = affinity.Tolerations
-		}
-
-		// Update the job securityContext, podLabels and resourceRequirements from moverConfig (if specified)
-		utils.UpdatePodTemplateSpecFromMoverConfig(&job.Spec.Template, m.moverConfig, corev1.ResourceRequirements{})
-
-		// Update the job volumes/mounts for additional mover volumes (if specified)
-		if err := utils.UpdatePodTemplateSpecWithMoverVolumes(ctx, m.client, logger, m.owner.GetNamespace(),
-			&job.Spec.Template, m.moverVolumes); err != nil {
+		if err := m.configurePodTemplate(ctx, logger, &job.Spec.Template, dataPVC, sa, rsyncSecretName,
+			tlsCAObj); err != nil {
 			return err
 		}
-
-		if m.privileged {
-			podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, corev1.EnvVar{
-				Name:  "PRIVILEGED_MOVER",
-				Value: "1",
-			})
-			podSpec.Containers[0].SecurityContext.Capabilities.Add = []corev1.Capability{
-				"DAC_OVERRIDE", // Read/write all files
-				"CHOWN",        // chown files
-				"FOWNER",       // Set permission bits & times
-				"SETGID",       // Set process GID/supplemental groups
-			}
-			podSpec.Containers[0].SecurityContext.RunAsUser = ptr.To[int64](0)
-		} else {
-			podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, corev1.EnvVar{
-				Name:  "PRIVILEGED_MOVER",
-				Value: "0",
-			})
-		}
-
-		tlsProfileSpec, err := platform.GetTLSProfileIfOpenShift(ctx, m.client, logger)
-		if err != nil {
-			return err
-		}
-		if tlsProfileSpec != nil {
-			// TLS ProfileSpec is set, this is OpenShift
-			minTLSVersion, err := platform.ParseTLSVersionForStunnelPSK(tlsProfileSpec.MinTLSVersion)
-			if err != nil {
-				logger.Error(err, "Unable to parse minTLSVersion from TLSProfileSpec",
-					"tlsProfileSpec.MinTLSVersion", tlsProfileSpec.MinTLSVersion)
-				return err
-			}
-			// Set env var to set sslVersionMin in sTunnel
-			podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, corev1.EnvVar{
-				Name:  "SSL_VERSION_MIN",
-				Value: minTLSVersion,
-			})
-		}
-
-		// Run mover in debug mode if required
-		podSpec.Containers[0].Env = utils.AppendDebugMoverEnvVar(m.owner, podSpec.Containers[0].Env)
+		job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
 
 		logger.V(1).Info("Job has PVC", "PVC", dataPVC, "DS", dataPVC.Spec.DataSource)
 		return nil
@@ -564,7 +652,239 @@ func (m *Mover) ensureJob(ctx context.Context, dataPVC *corev1.PersistentVolumeC
 	// update status with mover logs from successful job
 	utils.UpdateMoverStatusForSuccessfulJob(ctx, m.logger, m.latestMoverStatus, job.GetName(), job.GetNamespace(),
 		LogLineFilterSuccess)
+	if m.certificate == nil {
+		m.updateStatusLastPSKIdentity()
+	}
 
 	// We only continue reconciling if the rsync job has completed
 	return job, nil
 }
+
+// validateTLSCA validates the CA bundle used to verify certificates, if one
+// is configured
+func (m *Mover) validateTLSCA(ctx context.Context) (utils.CustomCAObject, error) {
+	if m.certificate == nil || m.certificate.CABundle == nil {
+		return nil, nil
+	}
+	tlsCAObj, err := utils.ValidateCustomCA(ctx, m.client, m.logger, m.owner.GetNamespace(),
+		*m.certificate.CABundle)
+	if err != nil {
+		return nil, err
+	}
+	if tlsCAObj == nil {
+		err := errors.New("caBundle requires a key and either a secretName or a configMapName")
+		m.logger.Error(err, "RsyncTLS Spec validation error")
+		return nil, err
+	}
+	return tlsCAObj, nil
+}
+
+// configurePodTemplate sets up the mover Pod, shared by the mover Job and the
+// persistent destination Deployment
+//
+//nolint:funlen
+func (m *Mover) configurePodTemplate(ctx context.Context, logger logr.Logger, template *corev1.PodTemplateSpec,
+	dataPVC *corev1.PersistentVolumeClaim, sa *corev1.ServiceAccount, rsyncSecretName string,
+	tlsCAObj utils.CustomCAObject) error {
+	readOnlyVolume := false
+	blockVolume := utils.PvcIsBlockMode(dataPVC)
+
+	containerEnv := []corev1.EnvVar{}
+	containerCmd := []string{"/bin/bash", "-c", "/mover-rsync-tls/server.sh"} // cmd for replicationDestination job
+	if m.isSource {
+		// Set dest address/port if necessary
+		if m.address != nil {
+			containerEnv = append(containerEnv, corev1.EnvVar{Name: "DESTINATION_ADDRESS", Value: *m.address})
+			// Send the hostname via SNI so that a router or Gateway can pass
+			// the connection through to the destination
+			if net.ParseIP(strings.Trim(*m.address, "[]")) == nil {
+				containerEnv = append(containerEnv, corev1.EnvVar{Name: "TLS_SERVER_NAME", Value: *m.address})
+			}
+		}
+		if m.port != nil {
+			connectPort := strconv.Itoa(int(*m.port))
+			containerEnv = append(containerEnv, corev1.EnvVar{Name: "DESTINATION_PORT", Value: connectPort})
+		}
+		if m.pskIdentity != nil {
+			containerEnv = append(containerEnv, corev1.EnvVar{Name: "PSK_IDENTITY", Value: *m.pskIdentity})
+		}
+		// Options to tune the rsync transfer
+		containerEnv = append(containerEnv, utils.RsyncTuningEnvVars(m.tuning)...)
+		// Options for the transfer of Block volumes
+		containerEnv = append(containerEnv, m.blockTransferEnvVars()...)
+
+		// Set container cmd for the replicationSource job
+		containerCmd = []string{"/bin/bash", "-c", "/mover-rsync-tls/client.sh"}
+
+		// Set read-only for volume in repl source job spec if the PVC only supports read-only
+		readOnlyVolume = utils.PvcIsReadOnly(dataPVC)
+	}
+	podSpec := &template.Spec
+	podSpec.Containers = []corev1.Container{{
+		Name:    "rsync-tls",
+		Env:     containerEnv,
+		Command: containerCmd,
+		Image:   m.containerImage,
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			Privileged:             ptr.To(false),
+			ReadOnlyRootFilesystem: ptr.To(true),
+		},
+	}}
+	volumeMounts := []corev1.VolumeMount{}
+	if !blockVolume {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: dataVolumeName, MountPath: mountPath})
+	}
+	volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: "keys", MountPath: "/keys"},
+		corev1.VolumeMount{Name: "tempdir", MountPath: "/tmp"})
+	podSpec.Containers[0].VolumeMounts = volumeMounts
+	if blockVolume {
+		podSpec.Containers[0].VolumeDevices = []corev1.VolumeDevice{
+			{Name: dataVolumeName, DevicePath: devicePath},
+		}
+	}
+	podSpec.ServiceAccountName = sa.Name
+	podSpec.Volumes = []corev1.Volume{
+		{Name: dataVolumeName, VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: dataPVC.Name,
+				ReadOnly:  readOnlyVolume,
+			}},
+		},
+		{Name: "keys", VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName:  rsyncSecretName,
+				DefaultMode: ptr.To[int32](0600),
+			}},
+		},
+		{Name: "tempdir", VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{
+				Medium: corev1.StorageMediumMemory,
+			}},
+		},
+	}
+	if m.vh.IsCopyMethodDirect() {
+		affinity, err := utils.AffinityFromVolume(ctx, m.client, logger, dataPVC)
+		if err != nil {
+			logger.Error(err, "unable to determine proper affinity", "PVC", client.ObjectKeyFromObject(dataPVC))
+			return err
+		}
+		podSpec.NodeSelector = affinity.NodeSelector
+		podSpec.Tolerations = affinity.Tolerations
+	}
+
+	if m.certificate != nil {
+		m.addCertificateToPodSpec(podSpec, tlsCAObj)
+	}
+
+	if m.isSource && m.changedBlocksConfig != nil {
+		m.addChangedBlocksToPodSpec(podSpec)
+	}
+
+	if !m.isSource && m.blockTransferStatePVC != nil {
+		m.addBlockTransferStateToPodSpec(podSpec)
+	}
+
+	if m.isSource {
+		utils.UpdatePodSpecWithRsyncExcludes(podSpec, m.excludePatterns, m.excludeConfigMap)
+	}
+
+	// Update the job securityContext, podLabels and resourceRequirements from moverConfig (if specified)
+	utils.UpdatePodTemplateSpecFromMoverConfig(template, m.moverConfig, corev1.ResourceRequirements{})
+
+	// Update the job volumes/mounts for additional mover volumes (if specified)
+	if err := utils.UpdatePodTemplateSpecWithMoverVolumes(ctx, m.client, logger, m.owner.GetNamespace(),
+		template, m.moverVolumes); err != nil {
+		return err
+	}
+
+	if m.privileged {
+		podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, corev1.EnvVar{
+			Name:  "PRIVILEGED_MOVER",
+			Value: "1",
+		})
+		podSpec.Containers[0].SecurityContext.Capabilities.Add = []corev1.Capability{
+			"DAC_OVERRIDE", // Read/write all files
+			"CHOWN",        // chown files
+			"FOWNER",       // Set permission bits & times
+			"SETGID",       // Set process GID/supplemental groups
+		}
+		podSpec.Containers[0].SecurityContext.RunAsUser = ptr.To[int64](0)
+	} else {
+		podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, corev1.EnvVar{
+			Name:  "PRIVILEGED_MOVER",
+			Value: "0",
+		})
+	}
+
+	tlsProfileSpec, err := platform.GetTLSProfileIfOpenShift(ctx, m.client, logger)
+	if err != nil {
+		return err
+	}
+	if tlsProfileSpec != nil {
+		// TLS ProfileSpec is set, this is OpenShift
+		parseTLSVersion := platform.ParseTLSVersionForStunnelPSK
+		if m.certificate != nil {
+			parseTLSVersion = platform.ParseTLSVersionForStunnel
+		}
+		minTLSVersion, err := parseTLSVersion(tlsProfileSpec.MinTLSVersion)
+		if err != nil {
+			logger.Error(err, "Unable to parse minTLSVersion from TLSProfileSpec",
+				"tlsProfileSpec.MinTLSVersion", tlsProfileSpec.MinTLSVersion)
+			return err
+		}
+		// Set env var to set sslVersionMin in sTunnel
+		podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, corev1.EnvVar{
+			Name:  "SSL_VERSION_MIN",
+			Value: minTLSVersion,
+		})
+
+		if m.certificate != nil {
+			// Certificate-based TLS also uses the ciphers from the profile
+			ciphers, ciphersuites := platform.GetStunnelCiphersFromProfile(*tlsProfileSpec)
+			if ciphers != "" {
+				podSpec.Containers[0].Env = append(podSpec.Containers[0].Env,
+					corev1.EnvVar{Name: "TLS_CIPHERS", Value: ciphers})
+			}
+			if ciphersuites != "" {
+				podSpec.Containers[0].Env = append(podSpec.Containers[0].Env,
+					corev1.EnvVar{Name: "TLS_CIPHERSUITES", Value: ciphersuites})
+			}
+		}
+	}
+
+	// Run mover in debug mode if required
+	podSpec.Containers[0].Env = utils.AppendDebugMoverEnvVar(m.owner, podSpec.Containers[0].Env)
+
+	return nil
+}
+
+// addCertificateToPodSpec configures the mover for mutual TLS with the
+// certificate Secret, which is mounted in place of the pre-shared key
+func (m *Mover) addCertificateToPodSpec(podSpec *corev1.PodSpec, tlsCAObj utils.CustomCAObject) {
+	caFile := path.Join(tlsKeysMountPath, corev1.ServiceAccountRootCAKey)
+	if tlsCAObj != nil {
+		caFile = path.Join(tlsCAMountPath, tlsCAFilename)
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      tlsCAVolumeName,
+			MountPath: tlsCAMountPath,
+		})
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name:         tlsCAVolumeName,
+			VolumeSource: tlsCAObj.GetVolumeSource(tlsCAFilename),
+		})
+	}
+
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env,
+		corev1.EnvVar{Name: "TLS_CERT_FILE", Value: path.Join(tlsKeysMountPath, corev1.TLSCertKey)},
+		corev1.EnvVar{Name: "TLS_KEY_FILE", Value: path.Join(tlsKeysMountPath, corev1.TLSPrivateKeyKey)},
+		corev1.EnvVar{Name: "TLS_CA_FILE", Value: caFile},
+	)
+	if m.certificate.PeerName != nil {
+		podSpec.Containers[0].Env = append(podSpec.Containers[0].Env,
+			corev1.EnvVar{Name: "TLS_CHECK_HOST", Value: *m.certificate.PeerName})
+	}
+}
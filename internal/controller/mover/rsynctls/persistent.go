@@ -0,0 +1,295 @@
+//go:build !disable_rsynctls
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsynctls
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+const (
+	// The persistent destination mover waits for the ID of the transfer it
+	// completed to be set in this Pod annotation before it accepts the next
+	// transfer
+	transferAckAnnotation = utils.VolsyncLabelPrefix + "/transfer-acknowledged"
+	// When the last transfer was acknowledged, only the logs since then are
+	// read for the next transfer
+	transferAckTimeAnnotation = utils.VolsyncLabelPrefix + "/transfer-acknowledged-at"
+	podInfoVolumeName         = "podinfo"
+	podInfoMountPath          = "/podinfo"
+	transferAckFilename       = "transfer-acknowledged"
+	// The persistent destination mover reports not ready while this file
+	// exists, from the completion of a transfer until it is acknowledged
+	transferPendingFile = "/tmp/transfer-pending"
+
+	// How often to check the persistent mover for a completed transfer. A
+	// completed transfer is also noticed when the mover stops being ready.
+	persistentPollInterval = 10 * time.Second
+	// Allowance for the clocks of the node and the controller when reading
+	// the logs since the last acknowledged transfer
+	transferLogClockSkew = time.Minute
+	// How often to check for the image of a completed transfer, so that the
+	// mover is acknowledged soon after it is ready
+	persistentImageInterval = 2 * time.Second
+)
+
+// synchronizePersistent waits for the next transfer to the long-running
+// destination mover to complete, creates the image and then allows the mover
+// to accept the following transfer.
+func (m *Mover) synchronizePersistent(ctx context.Context, dataPVC *corev1.PersistentVolumeClaim,
+	sa *corev1.ServiceAccount, rsyncSecretName string) (mover.Result, error) {
+	deployment, err := m.ensureDeployment(ctx, dataPVC, sa, rsyncSecretName)
+	if deployment == nil || err != nil {
+		return mover.InProgress(), err
+	}
+
+	pod, err := m.getPersistentPod(ctx)
+	if pod == nil || err != nil {
+		return mover.RetryAfter(persistentPollInterval), err
+	}
+
+	if podIsReady(pod) {
+		// The mover only reports not ready while a completed transfer waits
+		// to be acknowledged
+		return mover.RetryAfter(persistentPollInterval), nil
+	}
+
+	var sinceTime *metav1.Time
+	if ackTime, err := time.Parse(time.RFC3339, pod.GetAnnotations()[transferAckTimeAnnotation]); err == nil {
+		sinceTime = &metav1.Time{Time: ackTime.Add(-transferLogClockSkew)}
+	}
+	logs, err := utils.GetFilteredPodLogs(ctx, m.logger, pod.GetName(), pod.GetNamespace(), sinceTime,
+		LogLineFilterSuccess)
+	if err != nil {
+		return mover.RetryAfter(persistentPollInterval), nil
+	}
+	transferID, transferLogs := lastCompletedTransfer(logs)
+	if transferID == "" || pod.GetAnnotations()[transferAckAnnotation] == transferID {
+		// No new transfer has completed
+		return mover.RetryAfter(persistentPollInterval), nil
+	}
+
+	m.latestMoverStatus.Result = volsyncv1alpha1.MoverResultSuccessful
+	m.latestMoverStatus.Logs = utils.TruncateString(transferLogs, utils.GetMoverLogMaxBytes())
+	if m.certificate == nil {
+		m.updateStatusLastPSKIdentity()
+	}
+
+	image, err := m.vh.EnsureImage(ctx, m.logger, dataPVC)
+	if image == nil || err != nil {
+		return mover.RetryAfter(persistentImageInterval), err
+	}
+
+	// The image has been preserved, let the mover accept the next transfer
+	patch := client.MergeFrom(pod.DeepCopy())
+	metav1.SetMetaDataAnnotation(&pod.ObjectMeta, transferAckAnnotation, transferID)
+	metav1.SetMetaDataAnnotation(&pod.ObjectMeta, transferAckTimeAnnotation, time.Now().UTC().Format(time.RFC3339))
+	if err := m.client.Patch(ctx, pod, patch); err != nil {
+		m.logger.Error(err, "unable to acknowledge transfer", "pod", client.ObjectKeyFromObject(pod))
+		return mover.InProgress(), err
+	}
+	m.logger.Info("transfer completed", "transfer", transferID)
+
+	return mover.CompleteWithImage(image), nil
+}
+
+// lastCompletedTransfer returns the ID of the last transfer the persistent
+// mover reported as completed along with the logs of that transfer
+func lastCompletedTransfer(logs string) (string, string) {
+	lines := strings.Split(logs, "\n")
+	last := -1
+	previous := -1
+	for i, line := range lines {
+		if transferCompletedRegex.MatchString(line) {
+			previous = last
+			last = i
+		}
+	}
+	if last < 0 {
+		return "", ""
+	}
+	transferID := transferCompletedRegex.FindStringSubmatch(lines[last])[1]
+	return transferID, strings.Join(lines[previous+1:last+1], "\n")
+}
+
+// podIsReady returns whether the Ready condition of the Pod is true
+func podIsReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func (m *Mover) persistentDeploymentName() string {
+	return utils.GetJobName(m.jobNamePrefix(), m.owner)
+}
+
+//nolint:funlen
+func (m *Mover) ensureDeployment(ctx context.Context, dataPVC *corev1.PersistentVolumeClaim,
+	sa *corev1.ServiceAccount, rsyncSecretName string) (*appsv1.Deployment, error) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.persistentDeploymentName(),
+			Namespace: m.owner.GetNamespace(),
+		},
+	}
+	logger := m.logger.WithValues("deployment", client.ObjectKeyFromObject(deployment))
+
+	tlsCAObj, err := m.validateTLSCA(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := ctrlutil.CreateOrUpdate(ctx, m.client, deployment, func() error {
+		if err := ctrl.SetControllerReference(m.owner, deployment, m.client.Scheme()); err != nil {
+			logger.Error(err, utils.ErrUnableToSetControllerRef)
+			return err
+		}
+		// Not marked for cleanup, the Deployment accepts successive
+		// synchronizations
+		utils.SetOwnedByVolSync(deployment)
+
+		replicas := int32(1)
+		if m.paused {
+			replicas = 0
+		}
+		deployment.Spec.Replicas = &replicas
+		deployment.Spec.Selector = &metav1.LabelSelector{
+			MatchLabels: m.serviceSelector(),
+		}
+		// Only one mover may write to the destination volume at a time
+		deployment.Spec.Strategy = appsv1.DeploymentStrategy{
+			Type: appsv1.RecreateDeploymentStrategyType,
+		}
+
+		deployment.Spec.Template.Name = deployment.Name
+		utils.AddAllLabels(&deployment.Spec.Template, m.serviceSelector())
+		utils.SetOwnedByVolSync(&deployment.Spec.Template)
+
+		if err := m.configurePodTemplate(ctx, logger, &deployment.Spec.Template, dataPVC, sa, rsyncSecretName,
+			tlsCAObj); err != nil {
+			return err
+		}
+
+		podSpec := &deployment.Spec.Template.Spec
+		podSpec.RestartPolicy = corev1.RestartPolicyAlways
+		podSpec.Containers[0].Env = append(podSpec.Containers[0].Env,
+			corev1.EnvVar{Name: "PERSISTENT", Value: "1"},
+			corev1.EnvVar{Name: "TRANSFER_ACK_FILE", Value: podInfoMountPath + "/" + transferAckFilename},
+			corev1.EnvVar{Name: "TRANSFER_PENDING_FILE", Value: transferPendingFile},
+		)
+		// The change of readiness when a transfer completes updates the
+		// Deployment status, which triggers a reconcile
+		podSpec.Containers[0].ReadinessProbe = &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				Exec: &corev1.ExecAction{
+					Command: []string{"/bin/bash", "-c", "[[ ! -e " + transferPendingFile + " ]]"},
+				},
+			},
+			// All set, so that the defaults don't cause an update each time
+			PeriodSeconds:    2,
+			TimeoutSeconds:   1,
+			SuccessThreshold: 1,
+			FailureThreshold: 1,
+		}
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts,
+			corev1.VolumeMount{Name: podInfoVolumeName, MountPath: podInfoMountPath})
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: podInfoVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				DownwardAPI: &corev1.DownwardAPIVolumeSource{
+					Items: []corev1.DownwardAPIVolumeFile{{
+						Path: transferAckFilename,
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: "metadata.annotations['" + transferAckAnnotation + "']",
+						},
+					}},
+					DefaultMode: ptr.To[int32](0644),
+				},
+			},
+		})
+		return nil
+	})
+	if err != nil {
+		logger.Error(err, "reconcile failed")
+		return nil, err
+	}
+
+	logger.V(1).Info("Deployment reconciled", "operation", op)
+	if op == ctrlutil.OperationResultCreated {
+		m.eventRecorder.Eventf(m.owner, deployment, corev1.EventTypeNormal,
+			volsyncv1alpha1.EvRTransferStarted, volsyncv1alpha1.EvACreateMover, "starting %s to receive data",
+			utils.KindAndName(m.client.Scheme(), deployment))
+	}
+	return deployment, nil
+}
+
+// getPersistentPod returns the running Pod of the persistent destination
+// mover, if any
+func (m *Mover) getPersistentPod(ctx context.Context) (*corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := m.client.List(ctx, pods, client.InNamespace(m.owner.GetNamespace()),
+		client.MatchingLabels(m.serviceSelector())); err != nil {
+		m.logger.Error(err, "unable to list mover pods")
+		return nil, err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp.IsZero() {
+			return pod, nil
+		}
+	}
+	return nil, nil
+}
+
+// deletePersistentDeployment removes the Deployment of a destination that is
+// no longer persistent
+func (m *Mover) deletePersistentDeployment(ctx context.Context) error {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.persistentDeploymentName(),
+			Namespace: m.owner.GetNamespace(),
+		},
+	}
+	if err := m.client.Get(ctx, client.ObjectKeyFromObject(deployment), deployment); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(deployment, m.owner) {
+		return nil
+	}
+	m.logger.Info("deleting persistent mover deployment", "deployment", client.ObjectKeyFromObject(deployment))
+	err := m.client.Delete(ctx, deployment, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	return client.IgnoreNotFound(err)
+}
@@ -0,0 +1,303 @@
+//go:build !disable_rsynctls
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsynctls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+const (
+	// Routers and Gateways accept TLS connections on the HTTPS port
+	routerTLSPort = int32(443)
+)
+
+var routeGroupKind = schema.GroupKind{
+	Group: routev1.GroupName,
+	Kind:  "Route",
+}
+
+// TLSRoute is served as v1alpha2 by the experimental channel of the Gateway
+// API, and as v1 by the standard channel. The spec fields used here are the
+// same in both.
+var tlsRouteGroupKind = schema.GroupKind{
+	Group: "gateway.networking.k8s.io",
+	Kind:  "TLSRoute",
+}
+
+// tlsRouteGVK returns the preferred version of TLSRoute served by the cluster
+func tlsRouteGVK(mapper apimeta.RESTMapper) (schema.GroupVersionKind, error) {
+	mapping, err := mapper.RESTMapping(tlsRouteGroupKind)
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	return mapping.GroupVersionKind, nil
+}
+
+// validateRoutes checks that at most one way of exposing the Service through a
+// router is set
+func (m *Mover) validateRoutes() error {
+	if m.route != nil && m.tlsRoute != nil {
+		err := errors.New("route and tlsRoute can not be used together")
+		m.logger.Error(err, "RsyncTLS Spec validation error")
+		return err
+	}
+	return nil
+}
+
+// ensureRouteAndPublishAddress exposes the Service through an OpenShift Route
+// with TLS passthrough and publishes the hostname assigned to the Route. The
+// router selects the destination using the SNI sent by the source.
+func (m *Mover) ensureRouteAndPublishAddress(ctx context.Context, service *corev1.Service) (bool, error) {
+	route := &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      service.GetName(),
+			Namespace: service.GetNamespace(),
+		},
+	}
+	logger := m.logger.WithValues("route", client.ObjectKeyFromObject(route))
+
+	op, err := ctrlutil.CreateOrUpdate(ctx, m.client, route, func() error {
+		if err := ctrl.SetControllerReference(m.owner, route, m.client.Scheme()); err != nil {
+			logger.Error(err, utils.ErrUnableToSetControllerRef)
+			return err
+		}
+		utils.SetOwnedByVolSync(route)
+
+		if m.route.Host != nil {
+			route.Spec.Host = *m.route.Host
+		}
+		route.Spec.To = routev1.RouteTargetReference{
+			Kind:   "Service",
+			Name:   service.GetName(),
+			Weight: ptr.To[int32](100),
+		}
+		route.Spec.Port = &routev1.RoutePort{
+			TargetPort: intstr.FromString(service.Spec.Ports[0].Name),
+		}
+		route.Spec.TLS = &routev1.TLSConfig{
+			Termination:                   routev1.TLSTerminationPassthrough,
+			InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyNone,
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error(err, "Route reconcile failed")
+		return false, err
+	}
+	logger.V(1).Info("Route reconciled", "operation", op)
+
+	return m.publishRouteAddress(route, getRouteHost(route))
+}
+
+// getRouteHost returns the hostname of the Route once it has been admitted by
+// a router
+func getRouteHost(route *routev1.Route) string {
+	for _, ingress := range route.Status.Ingress {
+		for _, condition := range ingress.Conditions {
+			if condition.Type == routev1.RouteAdmitted && condition.Status == corev1.ConditionTrue &&
+				ingress.Host != "" {
+				return ingress.Host
+			}
+		}
+	}
+	return ""
+}
+
+// ensureTLSRouteAndPublishAddress exposes the Service through a Gateway API
+// TLSRoute and publishes its hostname once the Gateway has accepted it. The
+// Gateway selects the destination using the SNI sent by the source.
+func (m *Mover) ensureTLSRouteAndPublishAddress(ctx context.Context, service *corev1.Service) (bool, error) {
+	gvk, err := tlsRouteGVK(m.client.RESTMapper())
+	if err != nil {
+		if utils.IsCRDNotPresentError(err) {
+			err = fmt.Errorf("TLSRoute is not served by the cluster, the Gateway API CRDs must be installed: %w", err)
+		}
+		m.logger.Error(err, "TLSRoute reconcile failed")
+		return false, err
+	}
+	tlsRoute := &unstructured.Unstructured{}
+	tlsRoute.SetGroupVersionKind(gvk)
+	tlsRoute.SetName(service.GetName())
+	tlsRoute.SetNamespace(service.GetNamespace())
+	logger := m.logger.WithValues("tlsRoute", client.ObjectKeyFromObject(tlsRoute))
+
+	op, err := ctrlutil.CreateOrUpdate(ctx, m.client, tlsRoute, func() error {
+		if err := ctrl.SetControllerReference(m.owner, tlsRoute, m.client.Scheme()); err != nil {
+			logger.Error(err, utils.ErrUnableToSetControllerRef)
+			return err
+		}
+		utils.SetOwnedByVolSync(tlsRoute)
+
+		return unstructured.SetNestedField(tlsRoute.Object,
+			tlsRouteSpec(m.tlsRoute, service), "spec")
+	})
+	if err != nil {
+		logger.Error(err, "TLSRoute reconcile failed")
+		return false, err
+	}
+	logger.V(1).Info("TLSRoute reconciled", "operation", op)
+
+	hostname := ""
+	if tlsRouteAccepted(tlsRoute) {
+		hostname = m.tlsRoute.Hostname
+	}
+	return m.publishRouteAddress(tlsRoute, hostname)
+}
+
+// tlsRouteSpec returns the spec of a TLSRoute that attaches the Service to the
+// Gateway listener
+func tlsRouteSpec(spec *volsyncv1alpha1.RsyncTLSGatewayRouteSpec, service *corev1.Service) map[string]interface{} {
+	parentRef := map[string]interface{}{
+		"name": spec.GatewayName,
+	}
+	if spec.GatewayNamespace != nil {
+		parentRef["namespace"] = *spec.GatewayNamespace
+	}
+	if spec.SectionName != nil {
+		parentRef["sectionName"] = *spec.SectionName
+	}
+	return map[string]interface{}{
+		"parentRefs": []interface{}{parentRef},
+		"hostnames":  []interface{}{spec.Hostname},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": service.GetName(),
+						"port": int64(service.Spec.Ports[0].Port),
+					},
+				},
+			},
+		},
+	}
+}
+
+// tlsRouteAccepted returns whether a Gateway has accepted the TLSRoute
+func tlsRouteAccepted(tlsRoute *unstructured.Unstructured) bool {
+	parents, _, _ := unstructured.NestedSlice(tlsRoute.Object, "status", "parents")
+	for _, parent := range parents {
+		parentMap, ok := parent.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditions, _, _ := unstructured.NestedSlice(parentMap, "conditions")
+		for _, condition := range conditions {
+			conditionMap, ok := condition.(map[string]interface{})
+			if ok && conditionMap["type"] == "Accepted" && conditionMap["status"] == string(metav1.ConditionTrue) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// removeUnusedRoutes deletes the Route or TLSRoute that was created for the
+// Service when the spec no longer asks for it, so that a router does not keep
+// sending connections to the destination through the previous hostname
+func (m *Mover) removeUnusedRoutes(ctx context.Context, service *corev1.Service) error {
+	if m.route == nil {
+		if err := m.deleteRoute(ctx, routeGroupKind, service); err != nil {
+			return err
+		}
+	}
+	if m.tlsRoute == nil {
+		if err := m.deleteRoute(ctx, tlsRouteGroupKind, service); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteRoute deletes the route of the given kind for the Service if it is
+// owned by this ReplicationDestination
+func (m *Mover) deleteRoute(ctx context.Context, groupKind schema.GroupKind, service *corev1.Service) error {
+	mapping, err := m.client.RESTMapper().RESTMapping(groupKind)
+	if err != nil {
+		if utils.IsCRDNotPresentError(err) {
+			// The API is not available, nothing can have been created
+			return nil
+		}
+		return err
+	}
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(mapping.GroupVersionKind)
+	route.SetName(service.GetName())
+	route.SetNamespace(service.GetNamespace())
+	if err := m.client.Get(ctx, client.ObjectKeyFromObject(route), route); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(route, m.owner) {
+		return nil
+	}
+	logger := m.logger.WithValues("route", groupKind.Kind+"/"+route.GetName())
+	if err := m.client.Delete(ctx, route, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+		!kerrors.IsNotFound(err) {
+		logger.Error(err, "unable to delete unused route")
+		return err
+	}
+	logger.Info("Deleted unused route")
+	return nil
+}
+
+// publishRouteAddress publishes the hostname and port that sources use to
+// reach the destination through a router or Gateway
+func (m *Mover) publishRouteAddress(route client.Object, hostname string) (bool, error) {
+	if hostname == "" {
+		// The route hasn't been admitted yet, try again later
+		m.updateStatusAddress(nil)
+		if route.GetCreationTimestamp().Add(mover.ServiceAddressTimeout).Before(time.Now()) {
+			m.eventRecorder.Eventf(m.owner, route, corev1.EventTypeWarning,
+				volsyncv1alpha1.EvRSvcNoAddress, volsyncv1alpha1.EvANone,
+				"waiting for %s to be admitted",
+				utils.KindAndName(m.client.Scheme(), route))
+		}
+		return false, nil
+	}
+	m.updateStatusAddress(&hostname)
+
+	port := routerTLSPort
+	if m.tlsRoute != nil && m.tlsRoute.Port != nil {
+		port = *m.tlsRoute.Port
+	}
+	m.destStatus.Port = &port
+
+	m.logger.V(1).Info("Route addr published", "address", hostname)
+	return true, nil
+}
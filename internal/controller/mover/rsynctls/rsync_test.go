@@ -29,14 +29,19 @@ import (
 	. "github.com/onsi/gomega"
 
 	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	routev1 "github.com/openshift/api/route/v1"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
@@ -1005,10 +1010,201 @@ var _ = Describe("RsyncTLS as a source", func() {
 						Expect(err.Error()).To(ContainSubstring("not found"))
 					})
 				})
+				When("provided secret contains multiple identities", func() {
+					var secret *corev1.Secret
+					BeforeEach(func() {
+						secret = &corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-source-keys-multi",
+								Namespace: rs.Namespace,
+							},
+							StringData: map[string]string{
+								"psk.txt": "old:00000000000000000000000000000000\n" +
+									"new:11111111111111111111111111111111\n",
+							},
+						}
+						Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+						rs.Spec.RsyncTLS.KeySecret = &secret.Name
+					})
+					When("the pskIdentity is in the secret", func() {
+						BeforeEach(func() {
+							rs.Spec.RsyncTLS.PSKIdentity = ptr.To("new")
+						})
+						It("Mover should successfully ensureSecrets", func() {
+							keyName, err := mover.ensureSecrets(ctx)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(keyName).NotTo(BeNil())
+							Expect(*keyName).To(Equal(secret.GetName()))
+						})
+					})
+					When("the pskIdentity is not in the secret", func() {
+						BeforeEach(func() {
+							rs.Spec.RsyncTLS.PSKIdentity = ptr.To("missing")
+						})
+						It("Mover should fail to ensureSecrets", func() {
+							keyName, err := mover.ensureSecrets(ctx)
+							Expect(err).To(HaveOccurred())
+							Expect(keyName).To(BeNil())
+							Expect(err.Error()).To(ContainSubstring("missing"))
+						})
+					})
+				})
+				When("provided secret contains a duplicate identity", func() {
+					BeforeEach(func() {
+						secret := &corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-source-keys-dup",
+								Namespace: rs.Namespace,
+							},
+							StringData: map[string]string{
+								"psk.txt": "1:00000000000000000000000000000000\n" +
+									"1:11111111111111111111111111111111\n",
+							},
+						}
+						Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+						rs.Spec.RsyncTLS.KeySecret = &secret.Name
+					})
+					It("Mover should fail to ensureSecrets", func() {
+						keyName, err := mover.ensureSecrets(ctx)
+						Expect(err).To(HaveOccurred())
+						Expect(keyName).To(BeNil())
+						Expect(err.Error()).To(ContainSubstring("duplicate"))
+					})
+				})
+			})
+
+			When("a certificate is provided", func() {
+				var secret *corev1.Secret
+				BeforeEach(func() {
+					secret = &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "test-source-cert",
+							Namespace: rs.Namespace,
+						},
+						StringData: map[string]string{
+							"tls.crt": "cert",
+							"tls.key": "key",
+							"ca.crt":  "ca",
+						},
+					}
+					Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+					rs.Spec.RsyncTLS.Certificate = &volsyncv1alpha1.RsyncTLSCertificateSpec{
+						SecretName: secret.Name,
+					}
+				})
+				It("Mover should use the certificate Secret without generating a key", func() {
+					keyName, err := mover.ensureSecrets(ctx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(keyName).NotTo(BeNil())
+					Expect(*keyName).To(Equal(secret.GetName()))
+					Expect(rs.Status.RsyncTLS.KeySecret).To(BeNil())
+				})
+				When("a keySecret is also provided", func() {
+					BeforeEach(func() {
+						rs.Spec.RsyncTLS.KeySecret = ptr.To("test-source-keys")
+					})
+					It("Mover should fail to ensureSecrets", func() {
+						keyName, err := mover.ensureSecrets(ctx)
+						Expect(err).To(HaveOccurred())
+						Expect(keyName).To(BeNil())
+					})
+				})
+				When("the certificate Secret has no CA and no caBundle is provided", func() {
+					BeforeEach(func() {
+						noCASecret := &corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-source-cert-noca",
+								Namespace: rs.Namespace,
+							},
+							StringData: map[string]string{
+								"tls.crt": "cert",
+								"tls.key": "key",
+							},
+						}
+						Expect(k8sClient.Create(ctx, noCASecret)).To(Succeed())
+						rs.Spec.RsyncTLS.Certificate.SecretName = noCASecret.Name
+					})
+					It("Mover should fail to ensureSecrets", func() {
+						keyName, err := mover.ensureSecrets(ctx)
+						Expect(err).To(HaveOccurred())
+						Expect(keyName).To(BeNil())
+						Expect(err.Error()).To(ContainSubstring("ca.crt"))
+					})
+				})
 			})
 		})
 
 		//nolint:dupl
+		Context("Targets are handled properly", func() {
+			BeforeEach(func() {
+				keySecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "target-keys",
+						Namespace: ns.Name,
+					},
+					StringData: map[string]string{
+						"psk.txt": "1:00000000000000000000000000000000",
+					},
+				}
+				Expect(k8sClient.Create(ctx, keySecret)).To(Succeed())
+				rs.Spec.RsyncTLS.Targets = []volsyncv1alpha1.RsyncTLSTargetSpec{
+					{Name: "local", Address: "local.example.com", KeySecret: &keySecret.Name},
+					{Name: "remote", Address: "remote.example.com", Port: ptr.To[int32](9000),
+						KeySecret: &keySecret.Name},
+				}
+			})
+			It("Should create one mover Job per target", func() {
+				result, err := mover.synchronizeTargets(ctx, sPVC)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Completed).To(BeFalse())
+
+				for _, target := range rs.Spec.RsyncTLS.Targets {
+					job := &batchv1.Job{}
+					nsn := types.NamespacedName{Name: "volsync-rsync-tls-src-" + target.Name + "-" + rs.Name,
+						Namespace: ns.Name}
+					Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+					Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+						corev1.EnvVar{Name: "DESTINATION_ADDRESS", Value: target.Address}))
+				}
+
+				Expect(rs.Status.RsyncTLS.Targets).To(HaveLen(2))
+				Expect(rs.Status.RsyncTLS.Targets[0].Name).To(Equal("local"))
+				Expect(rs.Status.RsyncTLS.Targets[1].Name).To(Equal("remote"))
+			})
+			When("the status contains a target that was removed", func() {
+				JustBeforeEach(func() {
+					rs.Status.RsyncTLS.Targets = []volsyncv1alpha1.RsyncTLSTargetStatus{{Name: "old"}}
+				})
+				It("Should remove it from the status", func() {
+					_, err := mover.synchronizeTargets(ctx, sPVC)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(rs.Status.RsyncTLS.Targets).To(HaveLen(2))
+					Expect(rs.Status.RsyncTLS.Targets).NotTo(ContainElement(
+						HaveField("Name", "old")))
+				})
+			})
+			When("address is also set", func() {
+				BeforeEach(func() {
+					rs.Spec.RsyncTLS.Address = ptr.To("dest.example.com")
+				})
+				It("Should fail validation", func() {
+					_, err := mover.synchronizeTargets(ctx, sPVC)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("can not be used with targets"))
+				})
+			})
+			When("a target has no keySecret", func() {
+				BeforeEach(func() {
+					rs.Spec.RsyncTLS.Targets[1].KeySecret = nil
+				})
+				It("Should fail validation", func() {
+					_, err := mover.synchronizeTargets(ctx, sPVC)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("requires a keySecret"))
+				})
+			})
+		})
+
 		Context("ServiceAccount, Role, RoleBinding are handled properly", func() {
 			When("Mover is running privileged", func() {
 				It("Should create a service account with role that allows access to the scc", func() {
@@ -1163,6 +1359,55 @@ var _ = Describe("RsyncTLS as a source", func() {
 					Expect(job.Spec.Template.Spec.ServiceAccountName).To(Equal(sa.Name))
 				})
 
+				When("a certificate is used for mutual TLS", func() {
+					var caConfigMap *corev1.ConfigMap
+					BeforeEach(func() {
+						caConfigMap = &corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "internal-ca",
+								Namespace: ns.Name,
+							},
+							Data: map[string]string{"bundle.pem": "ca"},
+						}
+						Expect(k8sClient.Create(ctx, caConfigMap)).To(Succeed())
+						rs.Spec.RsyncTLS.Certificate = &volsyncv1alpha1.RsyncTLSCertificateSpec{
+							SecretName: tlsKeySecret.Name,
+							CABundle: &volsyncv1alpha1.CustomCASpec{
+								ConfigMapName: caConfigMap.Name,
+								Key:           "bundle.pem",
+							},
+							PeerName: ptr.To("dest.example.com"),
+						}
+					})
+					It("should configure the certificate and CA in the mover", func() {
+						j, e := mover.ensureJob(ctx, sPVC, sa, tlsKeySecret.GetName()) // Using sPVC as dataPVC (i.e. direct)
+						Expect(e).NotTo(HaveOccurred())
+						Expect(j).To(BeNil()) // hasn't completed
+						nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+						job = &batchv1.Job{}
+						Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+
+						c := job.Spec.Template.Spec.Containers[0]
+						Expect(c.Env).To(ContainElements(
+							corev1.EnvVar{Name: "TLS_CERT_FILE", Value: "/keys/tls.crt"},
+							corev1.EnvVar{Name: "TLS_KEY_FILE", Value: "/keys/tls.key"},
+							corev1.EnvVar{Name: "TLS_CA_FILE", Value: "/tls-ca/ca.crt"},
+							corev1.EnvVar{Name: "TLS_CHECK_HOST", Value: "dest.example.com"},
+						))
+						Expect(c.VolumeMounts).To(ContainElement(
+							corev1.VolumeMount{Name: tlsCAVolumeName, MountPath: tlsCAMountPath}))
+						var caVolume *corev1.Volume
+						for i := range job.Spec.Template.Spec.Volumes {
+							if job.Spec.Template.Spec.Volumes[i].Name == tlsCAVolumeName {
+								caVolume = &job.Spec.Template.Spec.Volumes[i]
+							}
+						}
+						Expect(caVolume).NotTo(BeNil())
+						Expect(caVolume.ConfigMap).NotTo(BeNil())
+						Expect(caVolume.ConfigMap.Name).To(Equal(caConfigMap.Name))
+					})
+				})
+
 				When("The ReplicationSource CR name is very long", func() {
 					BeforeEach(func() {
 						rs.Name = "very-long-name-will-cause-job-name-to-be-evenlongerthan63chars"
@@ -1514,6 +1759,156 @@ var _ = Describe("RsyncTLS as a source", func() {
 					// Validate job env vars
 					env := job.Spec.Template.Spec.Containers[0].Env
 					validateEnvVar(env, "DESTINATION_ADDRESS", address)
+					validateEnvVar(env, "TLS_SERVER_NAME", address)
+				})
+			})
+
+			When("initial sync and an IP address is specified in rsync spec", func() {
+				BeforeEach(func() {
+					rs.Spec.RsyncTLS.Address = ptr.To("10.1.2.3")
+				})
+				It("should not send the address as the TLS server name", func() {
+					j, e := mover.ensureJob(ctx, sPVC, sa, tlsKeySecret.GetName()) // Using sPVC as dataPVC (i.e. direct)
+					Expect(e).NotTo(HaveOccurred())
+					Expect(j).To(BeNil()) // hasn't completed
+					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+					job = &batchv1.Job{}
+					Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+
+					env := job.Spec.Template.Spec.Containers[0].Env
+					validateEnvVar(env, "DESTINATION_ADDRESS", "10.1.2.3")
+					for _, envVar := range env {
+						Expect(envVar.Name).NotTo(Equal("TLS_SERVER_NAME"))
+					}
+				})
+			})
+
+			When("resumable block transfers are enabled", func() {
+				BeforeEach(func() {
+					rs.Spec.RsyncTLS.BlockTransfer = &volsyncv1alpha1.RsyncTLSBlockTransferSpec{
+						Resumable: ptr.To(true),
+					}
+				})
+				It("should tell the mover to resume interrupted transfers", func() {
+					j, e := mover.ensureJob(ctx, sPVC, sa, tlsKeySecret.GetName()) // Using sPVC as dataPVC (i.e. direct)
+					Expect(e).NotTo(HaveOccurred())
+					Expect(j).To(BeNil()) // hasn't completed
+					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+					job = &batchv1.Job{}
+					Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+
+					validateEnvVar(job.Spec.Template.Spec.Containers[0].Env, "DISKRSYNC_RESUME", "1")
+				})
+			})
+
+			When("compression and parallel streams are configured", func() {
+				BeforeEach(func() {
+					rs.Spec.RsyncTLS.BlockTransfer = &volsyncv1alpha1.RsyncTLSBlockTransferSpec{
+						Compression:      ptr.To("zstd"),
+						CompressionLevel: ptr.To[int32](9),
+						Streams:          ptr.To[int32](4),
+					}
+				})
+				It("should pass the options to the mover", func() {
+					j, e := mover.ensureJob(ctx, sPVC, sa, tlsKeySecret.GetName()) // Using sPVC as dataPVC (i.e. direct)
+					Expect(e).NotTo(HaveOccurred())
+					Expect(j).To(BeNil()) // hasn't completed
+					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+					job = &batchv1.Job{}
+					Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+
+					env := job.Spec.Template.Spec.Containers[0].Env
+					validateEnvVar(env, "DISKRSYNC_COMPRESSION", "zstd")
+					validateEnvVar(env, "DISKRSYNC_COMPRESSION_LEVEL", "9")
+					validateEnvVar(env, "DISKRSYNC_STREAMS", "4")
+				})
+			})
+
+			When("checksums are enforced", func() {
+				BeforeEach(func() {
+					rs.Spec.RsyncTLS.BlockTransfer = &volsyncv1alpha1.RsyncTLSBlockTransferSpec{
+						Checksum: ptr.To("enforce"),
+					}
+				})
+				It("should pass the checksum mode to the mover", func() {
+					j, e := mover.ensureJob(ctx, sPVC, sa, tlsKeySecret.GetName()) // Using sPVC as dataPVC (i.e. direct)
+					Expect(e).NotTo(HaveOccurred())
+					Expect(j).To(BeNil()) // hasn't completed
+					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+					job = &batchv1.Job{}
+					Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+
+					validateEnvVar(job.Spec.Template.Spec.Containers[0].Env, "DISKRSYNC_CHECKSUM", "enforce")
+				})
+			})
+
+			When("changed blocks are enabled", func() {
+				BeforeEach(func() {
+					rs.Spec.RsyncTLS.CopyMethod = volsyncv1alpha1.CopyMethodSnapshot
+					rs.Spec.RsyncTLS.BlockTransfer = &volsyncv1alpha1.RsyncTLSBlockTransferSpec{
+						ChangedBlocks: ptr.To(true),
+					}
+				})
+				It("should allow the mover to get the snapshots", func() {
+					Expect(mover.changedBlocks).To(BeTrue())
+					sa, err := mover.saHandler.Reconcile(ctx, logger)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(sa).ToNot(BeNil())
+
+					role := &rbacv1.Role{}
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: sa.GetName(), Namespace: ns.Name},
+						role)).To(Succeed())
+					Expect(role.Rules).To(ContainElement(rbacv1.PolicyRule{
+						APIGroups: []string{"snapshot.storage.k8s.io"},
+						Resources: []string{"volumesnapshots"},
+						Verbs:     []string{"get", "list"},
+					}))
+				})
+				It("should not take the snapshot under the name of the base snapshot", func() {
+					name := mover.sourceDataName()
+					Expect(name).To(Equal("volsync-" + rs.GetName() + "-src"))
+					mover.sourceStatus.BaseSnapshot = ptr.To(name)
+					Expect(mover.sourceDataName()).To(Equal(name + "-b"))
+					mover.sourceStatus.BaseSnapshot = ptr.To(name + "-b")
+					Expect(mover.sourceDataName()).To(Equal(name))
+				})
+				It("should transfer the whole volume when the base snapshot is missing", func() {
+					mover.sourceStatus.BaseSnapshot = ptr.To("missing")
+					config, err := mover.getChangedBlocksConfig(ctx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(config).To(BeNil())
+				})
+				It("should give the mover access to the SnapshotMetadata service", func() {
+					mover.changedBlocksConfig = &changedBlocksConfig{
+						address:        "snapshot-metadata.csi-driver:6443",
+						caCert:         "my-ca",
+						audience:       "snapshot-metadata",
+						baseSnapshotID: "snap-1",
+						targetSnapshot: "volsync-src",
+					}
+					j, e := mover.ensureJob(ctx, sPVC, sa, tlsKeySecret.GetName()) // Using sPVC as dataPVC (i.e. direct)
+					Expect(e).NotTo(HaveOccurred())
+					Expect(j).To(BeNil()) // hasn't completed
+					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+					job = &batchv1.Job{}
+					Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+
+					env := job.Spec.Template.Spec.Containers[0].Env
+					validateEnvVar(env, "SNAPSHOT_METADATA_ADDRESS", "snapshot-metadata.csi-driver:6443")
+					validateEnvVar(env, "SNAPSHOT_METADATA_CA_CERT", "my-ca")
+					validateEnvVar(env, "SNAPSHOT_METADATA_TOKEN_FILE", "/var/run/secrets/snapshot-metadata/token")
+					validateEnvVar(env, "SNAPSHOT_NAMESPACE", ns.Name)
+					validateEnvVar(env, "BASE_SNAPSHOT_ID", "snap-1")
+					validateEnvVar(env, "TARGET_SNAPSHOT_NAME", "volsync-src")
+
+					var tokenVolume *corev1.Volume
+					for i, v := range job.Spec.Template.Spec.Volumes {
+						if v.Name == "snapshot-metadata-token" {
+							tokenVolume = &job.Spec.Template.Spec.Volumes[i]
+						}
+					}
+					Expect(tokenVolume).NotTo(BeNil())
+					Expect(tokenVolume.Projected.Sources[0].ServiceAccountToken.Audience).To(Equal("snapshot-metadata"))
 				})
 			})
 
@@ -1619,6 +2014,139 @@ var _ = Describe("RsyncTLS as a source", func() {
 	})
 })
 
+var _ = Describe("Persistent destination transfers", func() {
+	It("finds the most recent completed transfer", func() {
+		logs := `sent 24 bytes  received 90 bytes  total size 5
+Transfer 1-3f9a0c12 completed
+PSK identity used: new
+sent 40 bytes  received 3181 bytes  total size 3087
+Transfer 2-b07e44d1 completed`
+		transferID, transferLogs := lastCompletedTransfer(logs)
+		Expect(transferID).To(Equal("2-b07e44d1"))
+		Expect(transferLogs).To(Equal(`PSK identity used: new
+sent 40 bytes  received 3181 bytes  total size 3087
+Transfer 2-b07e44d1 completed`))
+	})
+	It("reports no transfer before the first one completes", func() {
+		transferID, transferLogs := lastCompletedTransfer("sent 24 bytes  received 90 bytes  total size 5")
+		Expect(transferID).To(BeEmpty())
+		Expect(transferLogs).To(BeEmpty())
+	})
+	It("only looks for a transfer while the mover is not ready", func() {
+		pod := &corev1.Pod{}
+		Expect(podIsReady(pod)).To(BeFalse())
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		Expect(podIsReady(pod)).To(BeTrue())
+		pod.Status.Conditions[0].Status = corev1.ConditionFalse
+		Expect(podIsReady(pod)).To(BeFalse())
+	})
+})
+
+var _ = Describe("Destination routes", func() {
+	It("publishes the host of an admitted Route", func() {
+		route := &routev1.Route{}
+		Expect(getRouteHost(route)).To(BeEmpty())
+
+		route.Status.Ingress = []routev1.RouteIngress{{
+			Host: "rd.apps.example.com",
+			Conditions: []routev1.RouteIngressCondition{{
+				Type:   routev1.RouteAdmitted,
+				Status: corev1.ConditionFalse,
+			}},
+		}}
+		Expect(getRouteHost(route)).To(BeEmpty())
+
+		route.Status.Ingress[0].Conditions[0].Status = corev1.ConditionTrue
+		Expect(getRouteHost(route)).To(Equal("rd.apps.example.com"))
+	})
+	It("attaches the TLSRoute to the Gateway listener", func() {
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "volsync-rsync-tls-dst-rd"},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: "rsync-tls", Port: 8000}},
+			},
+		}
+		spec := tlsRouteSpec(&volsyncv1alpha1.RsyncTLSGatewayRouteSpec{
+			Hostname:         "rd.example.com",
+			GatewayName:      "gateway",
+			GatewayNamespace: ptr.To("ingress"),
+			SectionName:      ptr.To("tls"),
+		}, service)
+		Expect(spec["hostnames"]).To(Equal([]interface{}{"rd.example.com"}))
+		Expect(spec["parentRefs"]).To(Equal([]interface{}{map[string]interface{}{
+			"name":        "gateway",
+			"namespace":   "ingress",
+			"sectionName": "tls",
+		}}))
+		backendRefs, found, err := unstructured.NestedSlice(spec["rules"].([]interface{})[0].(map[string]interface{}),
+			"backendRefs")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(backendRefs).To(Equal([]interface{}{map[string]interface{}{
+			"name": "volsync-rsync-tls-dst-rd",
+			"port": int64(8000),
+		}}))
+	})
+	It("waits for a Gateway to accept the TLSRoute", func() {
+		tlsRoute := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		Expect(tlsRouteAccepted(tlsRoute)).To(BeFalse())
+
+		Expect(unstructured.SetNestedSlice(tlsRoute.Object, []interface{}{
+			map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Accepted", "status": "False"},
+				},
+			},
+		}, "status", "parents")).To(Succeed())
+		Expect(tlsRouteAccepted(tlsRoute)).To(BeFalse())
+
+		Expect(unstructured.SetNestedSlice(tlsRoute.Object, []interface{}{
+			map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "ResolvedRefs", "status": "True"},
+					map[string]interface{}{"type": "Accepted", "status": "True"},
+				},
+			},
+		}, "status", "parents")).To(Succeed())
+		Expect(tlsRouteAccepted(tlsRoute)).To(BeTrue())
+	})
+	It("rejects a route and a tlsRoute together", func() {
+		m := &Mover{
+			logger:   zap.New(zap.UseDevMode(true), zap.WriteTo(GinkgoWriter)),
+			route:    &volsyncv1alpha1.RsyncTLSRouteSpec{},
+			tlsRoute: &volsyncv1alpha1.RsyncTLSGatewayRouteSpec{},
+		}
+		Expect(m.validateRoutes()).NotTo(Succeed())
+		m.route = nil
+		Expect(m.validateRoutes()).To(Succeed())
+	})
+	It("uses the TLSRoute version served by the cluster", func() {
+		mapper := apimeta.NewDefaultRESTMapper(nil)
+		_, err := tlsRouteGVK(mapper)
+		Expect(utils.IsCRDNotPresentError(err)).To(BeTrue())
+
+		// Only the experimental channel is installed
+		mapper = apimeta.NewDefaultRESTMapper([]schema.GroupVersion{
+			{Group: tlsRouteGroupKind.Group, Version: "v1alpha2"},
+		})
+		mapper.Add(tlsRouteGroupKind.WithVersion("v1alpha2"), apimeta.RESTScopeNamespace)
+		gvk, err := tlsRouteGVK(mapper)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gvk.Version).To(Equal("v1alpha2"))
+
+		// The standard channel serves v1 as the preferred version
+		mapper = apimeta.NewDefaultRESTMapper([]schema.GroupVersion{
+			{Group: tlsRouteGroupKind.Group, Version: "v1"},
+			{Group: tlsRouteGroupKind.Group, Version: "v1alpha2"},
+		})
+		mapper.Add(tlsRouteGroupKind.WithVersion("v1alpha2"), apimeta.RESTScopeNamespace)
+		mapper.Add(tlsRouteGroupKind.WithVersion("v1"), apimeta.RESTScopeNamespace)
+		gvk, err = tlsRouteGVK(mapper)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gvk.Version).To(Equal("v1"))
+	})
+})
+
 var _ = Describe("Rsync as a destination", func() {
 	var ns *corev1.Namespace
 	logger := zap.New(zap.UseDevMode(true), zap.WriteTo(GinkgoWriter))
@@ -1773,6 +2301,27 @@ var _ = Describe("Rsync as a destination", func() {
 					// It won't be cleaned up at the end of the transfer
 					Expect(pvc.Labels).NotTo(HaveKey("volsync.backube/cleanup"))
 				})
+				It("keeps the progress of resumable transfers on a state volume", func() {
+					pvc, e := mover.ensureDestinationPVC(ctx)
+					Expect(e).NotTo(HaveOccurred())
+					statePVC, e := mover.ensureBlockTransferStatePVC(ctx, pvc)
+					Expect(e).NotTo(HaveOccurred())
+					Expect(statePVC).NotTo(BeNil())
+					Expect(statePVC.Name).To(Equal("volsync-" + rd.Name + "-dst-state"))
+					Expect(*statePVC.Spec.VolumeMode).To(Equal(corev1.PersistentVolumeFilesystem))
+					Expect(statePVC.Spec.AccessModes).To(Equal(dPVC.Spec.AccessModes))
+					Expect(statePVC.OwnerReferences).To(HaveLen(1))
+					Expect(statePVC.Labels).NotTo(HaveKey("volsync.backube/cleanup"))
+
+					mover.blockTransferStatePVC = &statePVC.Name
+					podSpec := &corev1.PodSpec{Containers: []corev1.Container{{}}}
+					mover.addBlockTransferStateToPodSpec(podSpec)
+					Expect(podSpec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+						Name: "block-transfer-state", MountPath: "/state",
+					}))
+					Expect(podSpec.Volumes).To(HaveLen(1))
+					Expect(podSpec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal(statePVC.Name))
+				})
 			})
 		})
 
@@ -2110,6 +2659,47 @@ var _ = Describe("Rsync as a destination", func() {
 						[]string{"/bin/bash", "-c", "/mover-rsync-tls/server.sh"}))
 				})
 			})
+			When("the destination is persistent", func() {
+				BeforeEach(func() {
+					rd.Spec.RsyncTLS.Persistent = ptr.To(true)
+				})
+				It("should run the mover as a Deployment", func() {
+					d, e := mover.ensureDeployment(ctx, dPVC, sa, testKey)
+					Expect(e).NotTo(HaveOccurred())
+					Expect(d).NotTo(BeNil())
+
+					deployment := &appsv1.Deployment{}
+					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+					Expect(k8sClient.Get(ctx, nsn, deployment)).To(Succeed())
+					Expect(*deployment.Spec.Replicas).To(Equal(int32(1)))
+					Expect(deployment.Spec.Selector.MatchLabels).To(Equal(mover.serviceSelector()))
+					// Not removed after each synchronization
+					Expect(deployment.Labels).NotTo(HaveKey("volsync.backube/cleanup"))
+
+					podSpec := deployment.Spec.Template.Spec
+					Expect(podSpec.RestartPolicy).To(Equal(corev1.RestartPolicyAlways))
+					Expect(podSpec.Containers[0].Command).To(Equal(
+						[]string{"/bin/bash", "-c", "/mover-rsync-tls/server.sh"}))
+					Expect(podSpec.Containers[0].Env).To(ContainElements(
+						corev1.EnvVar{Name: "PERSISTENT", Value: "1"},
+						corev1.EnvVar{Name: "TRANSFER_ACK_FILE", Value: "/podinfo/transfer-acknowledged"},
+						corev1.EnvVar{Name: "TRANSFER_PENDING_FILE", Value: transferPendingFile},
+					))
+					Expect(podSpec.Volumes).To(ContainElement(HaveField("Name", podInfoVolumeName)))
+					// Not ready while a completed transfer waits to be acknowledged
+					probe := podSpec.Containers[0].ReadinessProbe
+					Expect(probe).NotTo(BeNil())
+					Expect(probe.Exec.Command).To(Equal(
+						[]string{"/bin/bash", "-c", "[[ ! -e /tmp/transfer-pending ]]"}))
+				})
+				It("should not wait for a Job", func() {
+					_, e := mover.synchronizePersistent(ctx, dPVC, sa, testKey)
+					Expect(e).NotTo(HaveOccurred())
+					job = &batchv1.Job{}
+					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+					Expect(kerrors.IsNotFound(k8sClient.Get(ctx, nsn, job))).To(BeTrue())
+				})
+			})
 		})
 
 		Context("Cleanup is handled properly", func() {
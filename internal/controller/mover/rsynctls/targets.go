@@ -0,0 +1,191 @@
+//go:build !disable_rsynctls
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package rsynctls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+// synchronizeTargets replicates the point-in-time copy of the source to each
+// of the targets, running one mover Job per target. The synchronization is
+// complete once the Jobs for all targets have completed.
+func (m *Mover) synchronizeTargets(ctx context.Context, dataPVC *corev1.PersistentVolumeClaim) (mover.Result, error) {
+	if err := m.validateTargets(); err != nil {
+		return mover.InProgress(), err
+	}
+	m.pruneTargetStatus()
+
+	// Prepare ServiceAccount, role, rolebinding
+	sa, err := m.saHandler.Reconcile(ctx, m.logger)
+	if sa == nil || err != nil {
+		return mover.InProgress(), err
+	}
+
+	// Validate MoverVolumes
+	err = utils.ValidateMoverVolumes(ctx, m.client, m.logger, m.owner.GetNamespace(), m.moverVolumes)
+	if err != nil {
+		return mover.InProgress(), err
+	}
+
+	completed := 0
+	failed := []string{}
+	for i := range m.targets {
+		tm := m.forTarget(&m.targets[i])
+
+		secretName, err := tm.ensureSecrets(ctx)
+		if secretName == nil || err != nil {
+			return mover.InProgress(), err
+		}
+
+		job, err := tm.ensureJob(ctx, dataPVC, sa, *secretName)
+		if err != nil {
+			return mover.InProgress(), err
+		}
+		if job == nil {
+			if tm.latestMoverStatus.Result == volsyncv1alpha1.MoverResultFailed {
+				failed = append(failed, tm.targetName)
+			}
+			continue
+		}
+
+		if job.Status.CompletionTime != nil {
+			tm.targetStatus.LastSyncTime = job.Status.CompletionTime
+			if job.Status.StartTime != nil {
+				tm.targetStatus.LastSyncDuration = &metav1.Duration{
+					Duration: job.Status.CompletionTime.Sub(job.Status.StartTime.Time),
+				}
+			}
+		}
+		completed++
+	}
+
+	if len(failed) > 0 {
+		utils.UpdateMoverStatusFailed(m.latestMoverStatus,
+			fmt.Sprintf("transfer failed for targets: %s", strings.Join(failed, ", ")))
+	}
+	if completed < len(m.targets) {
+		return mover.InProgress(), nil
+	}
+
+	m.latestMoverStatus.Result = volsyncv1alpha1.MoverResultSuccessful
+	m.latestMoverStatus.Logs = m.targetLogs()
+	return mover.Complete(), nil
+}
+
+// validateTargets checks that the targets can be used together with the rest
+// of the spec
+func (m *Mover) validateTargets() error {
+	var err error
+	if m.address != nil || m.port != nil || m.key != nil || m.pskIdentity != nil {
+		err = errors.New("address, port, keySecret and pskIdentity can not be used with targets")
+	}
+	names := map[string]bool{}
+	for _, target := range m.targets {
+		switch {
+		case err != nil:
+		case len(target.Name) == 0:
+			err = errors.New("each target requires a name")
+		case names[target.Name]:
+			err = fmt.Errorf("duplicate target name %q", target.Name)
+		case len(target.Address) == 0:
+			err = fmt.Errorf("target %q requires an address", target.Name)
+		case m.certificate == nil && target.KeySecret == nil:
+			err = fmt.Errorf("target %q requires a keySecret", target.Name)
+		}
+		names[target.Name] = true
+	}
+	if err != nil {
+		m.logger.Error(err, "RsyncTLS Spec validation error")
+	}
+	return err
+}
+
+// forTarget returns a Mover that transfers the data to a single target
+func (m *Mover) forTarget(target *volsyncv1alpha1.RsyncTLSTargetSpec) *Mover {
+	tm := *m
+	tm.logger = m.logger.WithValues("target", target.Name)
+	tm.targetName = target.Name
+	tm.targetStatus = m.getTargetStatus(target.Name)
+	if tm.targetStatus.LatestMoverStatus == nil {
+		tm.targetStatus.LatestMoverStatus = &volsyncv1alpha1.MoverStatus{}
+	}
+	tm.latestMoverStatus = tm.targetStatus.LatestMoverStatus
+	tm.address = &target.Address
+	tm.port = target.Port
+	tm.key = target.KeySecret
+	tm.pskIdentity = target.PSKIdentity
+	if m.certificate != nil && target.PeerName != nil {
+		certificate := *m.certificate
+		certificate.PeerName = target.PeerName
+		tm.certificate = &certificate
+	}
+	return &tm
+}
+
+// getTargetStatus returns the status entry for the named target, adding one
+// if necessary
+func (m *Mover) getTargetStatus(name string) *volsyncv1alpha1.RsyncTLSTargetStatus {
+	for i := range m.sourceStatus.Targets {
+		if m.sourceStatus.Targets[i].Name == name {
+			return &m.sourceStatus.Targets[i]
+		}
+	}
+	m.sourceStatus.Targets = append(m.sourceStatus.Targets, volsyncv1alpha1.RsyncTLSTargetStatus{Name: name})
+	return &m.sourceStatus.Targets[len(m.sourceStatus.Targets)-1]
+}
+
+// pruneTargetStatus removes the status of targets that are no longer in the
+// spec and pre-allocates the rest so that pointers to the entries remain
+// valid while the targets are reconciled
+func (m *Mover) pruneTargetStatus() {
+	statuses := make([]volsyncv1alpha1.RsyncTLSTargetStatus, 0, len(m.targets))
+	for _, target := range m.targets {
+		status := volsyncv1alpha1.RsyncTLSTargetStatus{Name: target.Name}
+		for _, existing := range m.sourceStatus.Targets {
+			if existing.Name == target.Name {
+				status = existing
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	m.sourceStatus.Targets = statuses
+}
+
+// targetLogs combines the mover logs of all targets
+func (m *Mover) targetLogs() string {
+	logs := []string{}
+	for _, status := range m.sourceStatus.Targets {
+		if status.LatestMoverStatus == nil {
+			continue
+		}
+		logs = append(logs, "target "+status.Name+":\n"+status.LatestMoverStatus.Logs)
+	}
+	return strings.Join(logs, "\n")
+}
@@ -83,6 +83,25 @@ var _ = Describe("Syncthing connection", func() {
 					Expect(serverState.Configuration.Version).To(Equal(9))
 				})
 
+				When("the server has a folder", func() {
+					BeforeEach(func() {
+						serverState.Configuration.Folders = []config.FolderConfiguration{{ID: "syncthing-folder-id"}}
+						serverState.Ignores = map[string][]string{"syncthing-folder-id": {"lost+found"}}
+					})
+
+					It("fetches the ignore patterns of the folder", func() {
+						ignores, err := syncthingConnection.FetchIgnores("syncthing-folder-id")
+						Expect(err).NotTo(HaveOccurred())
+						Expect(ignores).To(Equal([]string{"lost+found"}))
+					})
+
+					It("updates the ignore patterns of the folder", func() {
+						err := syncthingConnection.PublishIgnores("syncthing-folder-id", []string{"lost+found", "*.tmp"})
+						Expect(err).NotTo(HaveOccurred())
+						Expect(serverState.Ignores["syncthing-folder-id"]).To(Equal([]string{"lost+found", "*.tmp"}))
+					})
+				})
+
 			})
 
 			When("syncthingAPIConnection is making requests to the server", func() {
@@ -23,6 +23,7 @@ package api
 import (
 	"crypto/tls"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -34,6 +35,7 @@ const (
 	SystemStatusEndpoint      = "/rest/system/status"
 	SystemConnectionsEndpoint = "/rest/system/connections"
 	ConfigEndpoint            = "/rest/config"
+	IgnoresEndpoint           = "/rest/db/ignores"
 )
 
 // Fetch Pulls all of Syncthing's latest information from the API and stores it
@@ -64,6 +66,12 @@ func (s *syncthingAPIConnection) Fetch() (*Syncthing, error) {
 	}, nil
 }
 
+// FetchIgnores Pulls the ignore patterns of the given folder from the API.
+// They are not part of Fetch, as they are only needed when the patterns change.
+func (s *syncthingAPIConnection) FetchIgnores(folderID string) ([]string, error) {
+	return s.fetchIgnores(folderID)
+}
+
 // PublishConfig Updates the Syncthing API with the stored configuration data.
 // An error is returned in the case of a failure.
 func (s *syncthingAPIConnection) PublishConfig(conf config.Configuration) error {
@@ -76,6 +84,18 @@ func (s *syncthingAPIConnection) PublishConfig(conf config.Configuration) error
 	return err
 }
 
+// PublishIgnores Replaces the ignore patterns of the given folder.
+// An error is returned in the case of a failure.
+func (s *syncthingAPIConnection) PublishIgnores(folderID string, ignores []string) error {
+	s.logger.Info("Updating Syncthing ignore patterns", "folder", folderID)
+	_, err := s.jsonRequest(IgnoresEndpoint+"?folder="+url.QueryEscape(folderID), "POST",
+		Ignores{Ignore: ignores})
+	if err != nil {
+		s.logger.Error(err, "Failed to update Syncthing ignore patterns")
+	}
+	return err
+}
+
 // NewConnection accepts an APIConfig object and a logger and creates a SyncthingConnection
 // object in return.
 func NewConnection(cfg APIConfig, logger logr.Logger) SyncthingConnection {
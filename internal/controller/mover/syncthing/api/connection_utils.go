@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -140,6 +141,20 @@ func (api *syncthingAPIConnection) fetchSystemConnections() (*SystemConnections,
 	return responseBody, nil
 }
 
+// fetchIgnores Fetches the ignore patterns of the given folder from the Syncthing API.
+func (api *syncthingAPIConnection) fetchIgnores(folderID string) ([]string, error) {
+	responseBody := &Ignores{}
+	api.logger.Info("Fetching Syncthing ignore patterns", "folder", folderID)
+	data, err := api.jsonRequest(IgnoresEndpoint+"?folder="+url.QueryEscape(folderID), "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, responseBody); err != nil {
+		return nil, err
+	}
+	return responseBody.Ignore, nil
+}
+
 // checkResponse Returns an error if one exists in the response, or nil otherwise.
 // This function was extracted from the Syncthing repository
 // due to the overlapping functionality between our API access & the Syncthing CLI.
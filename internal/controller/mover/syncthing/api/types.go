@@ -92,14 +92,25 @@ type APIConfig struct {
 type SyncthingConnection interface {
 	// API Functions, these are meant to define communication with the Syncthing API.
 	Fetch() (*Syncthing, error)
+	FetchIgnores(folderID string) ([]string, error)
 	PublishConfig(config.Configuration) error
+	PublishIgnores(folderID string, ignores []string) error
 }
 
 // Syncthing Defines a Syncthing API object which contains a subset of the information
 // exposed through Syncthing's API. Namely, this struct exposes the configuration,
-// system status, and connections contained by the given object.
+// system status, and connections contained by the given object. The ignore
+// patterns of each folder, by folder ID, are not fetched with the rest and are
+// only held by the test server.
 type Syncthing struct {
 	Configuration     config.Configuration
 	SystemConnections SystemConnections
 	SystemStatus      SystemStatus
+	Ignores           map[string][]string
+}
+
+// Ignores Describes the contents of a folder's .stignore file, as exchanged with
+// the ignores endpoint.
+type Ignores struct {
+	Ignore []string `json:"ignore"`
 }
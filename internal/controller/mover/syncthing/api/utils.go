@@ -68,7 +68,7 @@ func (s *Syncthing) ShareFoldersWithDevices() {
 }
 
 // CreateSyncthingTestServer Returns a test server that mimics the Syncthing API by exposing
-// the endpoints for config, system status, and system connections.
+// the endpoints for config, system status, system connections, and ignores.
 // The server also accepts an API Key, which is used for authenticating between the client and server.
 //
 // The accepted arguments are pointers so that the state can be changed externally and the server
@@ -122,6 +122,26 @@ func CreateSyncthingTestServer(state *Syncthing, serverAPIKey string) *httptest.
 			resBytes, _ := json.Marshal(res)
 			fmt.Fprintln(w, string(resBytes))
 			return
+		case IgnoresEndpoint:
+			folderID := r.URL.Query().Get("folder")
+			switch r.Method {
+			case "GET":
+				resBytes, _ := json.Marshal(Ignores{Ignore: state.Ignores[folderID]})
+				fmt.Fprintln(w, string(resBytes))
+			case "POST":
+				ignores := Ignores{}
+				if err := json.NewDecoder(r.Body).Decode(&ignores); err != nil {
+					http.Error(w, "Error decoding request body", http.StatusBadRequest)
+					return
+				}
+				if state.Ignores == nil {
+					state.Ignores = map[string][]string{}
+				}
+				state.Ignores[folderID] = ignores.Ignore
+				resBytes, _ := json.Marshal(ignores)
+				fmt.Fprintln(w, string(resBytes))
+			}
+			return
 		default:
 			// the endpoint doesn't exist
 			http.Error(w, "the resource path doesn't exist", http.StatusNotFound)
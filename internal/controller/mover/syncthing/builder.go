@@ -141,6 +141,8 @@ func (rb *Builder) FromSource(client client.Client, logger logr.Logger,
 		privileged:          privileged,
 		moverConfig:         source.Spec.Syncthing.MoverConfig,
 		moverVolumes:        source.Spec.Syncthing.MoverVolumes,
+		ignorePatterns:      source.Spec.Syncthing.IgnorePatterns,
+		ignoreConfigMap:     source.Spec.Syncthing.IgnoreConfigMap,
 		// defer setting the VolumeHandler
 	}, nil
 }
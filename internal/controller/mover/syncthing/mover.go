@@ -24,7 +24,9 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -101,6 +103,10 @@ const (
 	configCapacity = "1Gi"
 )
 
+// defaultIgnorePatterns Are the patterns of the .stignore file created by the
+// Syncthing container, which are kept when the spec sets ignore patterns.
+var defaultIgnorePatterns = []string{"lost+found"}
+
 // Mover is the reconciliation logic for the Restic-based data mover.
 type Mover struct {
 	client              client.Client
@@ -122,6 +128,11 @@ type Mover struct {
 	privileged          bool
 	moverConfig         volsyncv1alpha1.MoverConfig
 	moverVolumes        []volsyncv1alpha1.MoverVolume
+	ignorePatterns      []string
+	ignoreConfigMap     *volsyncv1alpha1.ConfigMapKeySpec
+	// ignores are the patterns pushed to Syncthing, nil when the spec doesn't
+	// set any
+	ignores []string
 }
 
 var _ mover.Mover = &Mover{}
@@ -188,6 +199,10 @@ func (m *Mover) ensureNecessaryResources(ctx context.Context) (*corev1.Service,
 		return nil, nil, err
 	}
 
+	if err = m.loadIgnorePatterns(ctx); err != nil {
+		return nil, nil, err
+	}
+
 	sa, err := m.saHandler.Reconcile(ctx, m.logger)
 	if sa == nil || err != nil {
 		return nil, nil, err
@@ -244,6 +259,51 @@ func (m *Mover) interactWithSyncthing(dataService *corev1.Service, apiSecret *co
 	return m.ensureStatusIsUpdated(dataService, syncthingState)
 }
 
+// loadIgnorePatterns Validates the ignore patterns set in the spec and combines them with
+// the patterns of the ignore ConfigMap. The patterns are left nil when the spec doesn't set
+// any, so that the .stignore file of the folder isn't changed unless VolSync set it before.
+func (m *Mover) loadIgnorePatterns(ctx context.Context) error {
+	m.ignores = nil
+	if m.ignorePatterns == nil && m.ignoreConfigMap == nil {
+		return nil
+	}
+	for _, pattern := range m.ignorePatterns {
+		if len(strings.TrimSpace(pattern)) == 0 || strings.ContainsAny(pattern, "\r\n") {
+			err := fmt.Errorf("invalid ignore pattern: %q", pattern)
+			m.logger.Error(err, "Syncthing Spec validation error")
+			return err
+		}
+	}
+	ignores := append(slices.Clone(defaultIgnorePatterns), m.ignorePatterns...)
+
+	if m.ignoreConfigMap != nil {
+		if len(m.ignoreConfigMap.ConfigMapName) == 0 || len(m.ignoreConfigMap.Key) == 0 {
+			err := fmt.Errorf("ignoreConfigMap requires both configMapName and key")
+			m.logger.Error(err, "Syncthing Spec validation error")
+			return err
+		}
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      m.ignoreConfigMap.ConfigMapName,
+				Namespace: m.owner.GetNamespace(),
+			},
+		}
+		logger := m.logger.WithValues("ignoreConfigMap", client.ObjectKeyFromObject(configMap))
+		if err := utils.GetAndValidateConfigMap(ctx, m.client, logger, configMap, m.ignoreConfigMap.Key); err != nil {
+			return err
+		}
+		for _, line := range strings.Split(configMap.Data[m.ignoreConfigMap.Key], "\n") {
+			line = strings.TrimSuffix(line, "\r")
+			if len(strings.TrimSpace(line)) > 0 {
+				ignores = append(ignores, line)
+			}
+		}
+	}
+
+	m.ignores = ignores
+	return nil
+}
+
 // ensureConfigPVC Ensures that there is a PVC persisting Syncthing's config data.
 func (m *Mover) ensureConfigPVC(
 	ctx context.Context,
@@ -722,6 +782,9 @@ func (m *Mover) validatePeerList() error {
 //
 // If there is no User/Password set on the object, or a user is set but doesn't match the value in the secret,
 // then ensureIsConfigured will update the Syncthing state to match the values in the secret.
+//
+// When the spec sets ignore patterns, they replace those of each folder that differs.
+// Patterns that VolSync set are reset to the defaults once the spec no longer sets any.
 func (m *Mover) ensureIsConfigured(apiSecret *corev1.Secret, syncthing *api.Syncthing) error {
 	// nil check
 	if apiSecret == nil || syncthing == nil {
@@ -768,6 +831,46 @@ func (m *Mover) ensureIsConfigured(apiSecret *corev1.Secret, syncthing *api.Sync
 			return err
 		}
 	}
+
+	return m.ensureIgnores(syncthing)
+}
+
+// ensureIgnores Sets the ignore patterns of each folder when they differ from those
+// most recently set from the spec, which are recorded in the status. The patterns of
+// the folders are only fetched then.
+func (m *Mover) ensureIgnores(syncthing *api.Syncthing) error {
+	ignores := m.ignores
+	if ignores == nil {
+		if m.status.IgnorePatterns == nil {
+			// the .stignore file is managed by the user
+			return nil
+		}
+		// the spec no longer sets patterns, restore those of the Syncthing container
+		ignores = defaultIgnorePatterns
+	} else if slices.Equal(ignores, m.status.IgnorePatterns) {
+		return nil
+	}
+	if len(syncthing.Configuration.Folders) == 0 {
+		// nothing to set until Syncthing has created its folder
+		return nil
+	}
+
+	for _, folder := range syncthing.Configuration.Folders {
+		current, err := m.syncthingConnection.FetchIgnores(folder.ID)
+		if err != nil {
+			m.logger.Error(err, "error fetching syncthing ignore patterns")
+			return err
+		}
+		if slices.Equal(current, ignores) {
+			continue
+		}
+		m.logger.Info("setting ignore patterns", "folder", folder.ID)
+		if err := m.syncthingConnection.PublishIgnores(folder.ID, ignores); err != nil {
+			m.logger.Error(err, "error updating syncthing ignore patterns")
+			return err
+		}
+	}
+	m.status.IgnorePatterns = slices.Clone(m.ignores)
 	return nil
 }
 
@@ -744,6 +744,113 @@ var _ = Describe("When an RS specifies Syncthing", func() {
 					}
 				})
 
+				When("ignore patterns are set", func() {
+					var folderID = "syncthing-folder-id"
+
+					BeforeEach(func() {
+						syncthingState.Configuration.Folders = []config.FolderConfiguration{{ID: folderID}}
+						syncthingState.Ignores = map[string][]string{folderID: {"lost+found"}}
+						mover.ignorePatterns = []string{"*.tmp"}
+					})
+
+					It("pushes them to Syncthing", func() {
+						Expect(mover.loadIgnorePatterns(ctx)).To(Succeed())
+						syncthing, err := mover.syncthingConnection.Fetch()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(mover.ensureIsConfigured(apiKeys, syncthing)).To(Succeed())
+						Expect(syncthingState.Ignores[folderID]).To(Equal([]string{"lost+found", "*.tmp"}))
+						Expect(mover.status.IgnorePatterns).To(Equal([]string{"lost+found", "*.tmp"}))
+					})
+
+					It("only fetches the patterns of the folders when the spec changes", func() {
+						Expect(mover.loadIgnorePatterns(ctx)).To(Succeed())
+						syncthing, err := mover.syncthingConnection.Fetch()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(mover.ensureIsConfigured(apiKeys, syncthing)).To(Succeed())
+
+						// the patterns that were set are not compared again
+						syncthingState.Ignores[folderID] = []string{"custom"}
+						Expect(mover.ensureIsConfigured(apiKeys, syncthing)).To(Succeed())
+						Expect(syncthingState.Ignores[folderID]).To(Equal([]string{"custom"}))
+
+						mover.ignorePatterns = []string{"*.bak"}
+						Expect(mover.loadIgnorePatterns(ctx)).To(Succeed())
+						Expect(mover.ensureIsConfigured(apiKeys, syncthing)).To(Succeed())
+						Expect(syncthingState.Ignores[folderID]).To(Equal([]string{"lost+found", "*.bak"}))
+					})
+
+					It("resets them to the defaults once the spec no longer sets any", func() {
+						Expect(mover.loadIgnorePatterns(ctx)).To(Succeed())
+						syncthing, err := mover.syncthingConnection.Fetch()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(mover.ensureIsConfigured(apiKeys, syncthing)).To(Succeed())
+
+						mover.ignorePatterns = nil
+						Expect(mover.loadIgnorePatterns(ctx)).To(Succeed())
+						Expect(mover.ensureIsConfigured(apiKeys, syncthing)).To(Succeed())
+						Expect(syncthingState.Ignores[folderID]).To(Equal(defaultIgnorePatterns))
+						Expect(mover.status.IgnorePatterns).To(BeNil())
+
+						// the .stignore file is then left to the user
+						syncthingState.Ignores[folderID] = []string{"custom"}
+						Expect(mover.ensureIsConfigured(apiKeys, syncthing)).To(Succeed())
+						Expect(syncthingState.Ignores[folderID]).To(Equal([]string{"custom"}))
+					})
+
+					When("an ignore ConfigMap is also set", func() {
+						BeforeEach(func() {
+							configMap := &corev1.ConfigMap{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      "ignores",
+									Namespace: ns.Name,
+								},
+								Data: map[string]string{
+									"stignore": "cache/\r\n\n// application locks\n*.lock\n",
+								},
+							}
+							Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+							mover.ignoreConfigMap = &volsyncv1alpha1.ConfigMapKeySpec{
+								ConfigMapName: "ignores",
+								Key:           "stignore",
+							}
+						})
+
+						It("appends the patterns of the ConfigMap", func() {
+							Expect(mover.loadIgnorePatterns(ctx)).To(Succeed())
+							syncthing, err := mover.syncthingConnection.Fetch()
+							Expect(err).ToNot(HaveOccurred())
+							Expect(mover.ensureIsConfigured(apiKeys, syncthing)).To(Succeed())
+							Expect(syncthingState.Ignores[folderID]).To(Equal(
+								[]string{"lost+found", "*.tmp", "cache/", "// application locks", "*.lock"}))
+						})
+
+						It("fails if the key is missing", func() {
+							mover.ignoreConfigMap.Key = "missing"
+							Expect(mover.loadIgnorePatterns(ctx)).NotTo(Succeed())
+						})
+					})
+
+					It("rejects invalid patterns", func() {
+						mover.ignorePatterns = []string{"*.tmp\ncache/"}
+						Expect(mover.loadIgnorePatterns(ctx)).NotTo(Succeed())
+					})
+				})
+
+				When("no ignore patterns are set", func() {
+					BeforeEach(func() {
+						syncthingState.Configuration.Folders = []config.FolderConfiguration{{ID: "syncthing-folder-id"}}
+						syncthingState.Ignores = map[string][]string{"syncthing-folder-id": {"custom"}}
+					})
+
+					It("leaves the .stignore file alone", func() {
+						Expect(mover.loadIgnorePatterns(ctx)).To(Succeed())
+						syncthing, err := mover.syncthingConnection.Fetch()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(mover.ensureIsConfigured(apiKeys, syncthing)).To(Succeed())
+						Expect(syncthingState.Ignores["syncthing-folder-id"]).To(Equal([]string{"custom"}))
+					})
+				})
+
 				It("Ensures the status is updated", func() {
 					service := &corev1.Service{
 						ObjectMeta: metav1.ObjectMeta{
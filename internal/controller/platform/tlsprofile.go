@@ -20,6 +20,7 @@ package platform
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"crypto/tls"
 
@@ -114,3 +115,38 @@ func ParseTLSVersionForStunnelPSK(version ocpconfigv1.TLSProtocolVersion) (strin
 		return "", fmt.Errorf("unknown TLS version: %s", version)
 	}
 }
+
+// Parse string version of ocpconfigv1.TLSProtocolVersion in the format used by
+// stunnel for certificate-based TLS. Unlike TLS-PSK, certificate-based
+// connections use the minimum version from the TLS profile.
+func ParseTLSVersionForStunnel(version ocpconfigv1.TLSProtocolVersion) (string, error) {
+	switch version {
+	case ocpconfigv1.VersionTLS10:
+		return "TLSv1", nil
+	case ocpconfigv1.VersionTLS11:
+		return "TLSv1.1", nil
+	case ocpconfigv1.VersionTLS12:
+		return "TLSv1.2", nil
+	case ocpconfigv1.VersionTLS13:
+		return "TLSv1.3", nil
+	default:
+		return "", fmt.Errorf("unknown TLS version: %s", version)
+	}
+}
+
+// Split the ciphers of the TLS profile into the ciphers for TLS 1.2 and below
+// and the ciphersuites for TLS 1.3, as colon-separated lists that stunnel can
+// use
+func GetStunnelCiphersFromProfile(tlsProfileSpec ocpconfigv1.TLSProfileSpec) (ciphers string, ciphersuites string) {
+	tls12Ciphers := []string{}
+	tls13Ciphersuites := []string{}
+	for _, cipher := range tlsProfileSpec.Ciphers {
+		// The TLS 1.3 ciphersuites are the only ones using the IANA names
+		if strings.HasPrefix(cipher, "TLS_") {
+			tls13Ciphersuites = append(tls13Ciphersuites, cipher)
+		} else {
+			tls12Ciphers = append(tls12Ciphers, cipher)
+		}
+	}
+	return strings.Join(tls12Ciphers, ":"), strings.Join(tls13Ciphersuites, ":")
+}
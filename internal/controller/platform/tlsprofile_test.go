@@ -50,4 +50,36 @@ var _ = Describe("Test tlsprofile helper funcs", func() {
 			Expect(tlsVersion).To(Equal(""))
 		})
 	})
+
+	Describe("ParseTLSVersionForStunnel - certificate-based TLS", func() {
+		It("Should convert the TLS profile version into one sTunnel/openssl can use", func() {
+			tlsVersion, err := ParseTLSVersionForStunnel(ocpconfigv1.VersionTLS12)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tlsVersion).To(Equal("TLSv1.2"))
+
+			tlsVersion, err = ParseTLSVersionForStunnel(ocpconfigv1.VersionTLS13)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tlsVersion).To(Equal("TLSv1.3"))
+
+			// Unknown version
+			var fakeVersion ocpconfigv1.TLSProtocolVersion = "VersionNotExisting"
+			_, err = ParseTLSVersionForStunnel(fakeVersion)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("GetStunnelCiphersFromProfile", func() {
+		It("Should split the TLS 1.3 ciphersuites from the other ciphers", func() {
+			ciphers, ciphersuites := GetStunnelCiphersFromProfile(ocpconfigv1.TLSProfileSpec{
+				Ciphers: []string{
+					"TLS_AES_128_GCM_SHA256",
+					"TLS_AES_256_GCM_SHA384",
+					"ECDHE-ECDSA-AES128-GCM-SHA256",
+					"ECDHE-RSA-AES128-GCM-SHA256",
+				},
+			})
+			Expect(ciphers).To(Equal("ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256"))
+			Expect(ciphersuites).To(Equal("TLS_AES_128_GCM_SHA256:TLS_AES_256_GCM_SHA384"))
+		})
+	})
 })
@@ -91,6 +91,8 @@ var _ sm.ReplicationMachine = &rsMachine{}
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=security.openshift.io,resources=securitycontextconstraints,resourceNames=volsync-privileged-mover,verbs=use
 //+kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create;update;patch;delete;deletecollection
+//+kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshotcontents,verbs=get;list;watch
+//+kubebuilder:rbac:groups=cbt.storage.k8s.io,resources=snapshotmetadataservices,verbs=get;list;watch
 
 //nolint:funlen
 func (r *ReplicationSourceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -148,6 +150,11 @@ func (r *ReplicationSourceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		result, err = sm.Run(ctx, rsm, logger)
 	}
 
+	// Some movers also have maintenance to run independent of synchronization
+	if err == nil {
+		result, err = rsm.maintain(ctx, result)
+	}
+
 	// Update instance status
 	statusErr := r.Client.Status().Update(ctx, inst)
 	if err == nil { // Don't mask previous error
@@ -360,3 +367,25 @@ func (m *rsMachine) Synchronize(ctx context.Context) (mover.Result, error) {
 func (m *rsMachine) Cleanup(ctx context.Context) (mover.Result, error) {
 	return m.mover.Cleanup(ctx)
 }
+
+// maintain runs any maintenance that is due for movers that support it. The
+// returned result is adjusted so that we are reconciled again in time for the
+// next maintenance.
+func (m *rsMachine) maintain(ctx context.Context, result ctrl.Result) (ctrl.Result, error) {
+	maintainer, ok := m.mover.(mover.Maintainer)
+	if !ok {
+		return result, nil
+	}
+	mResult, err := maintainer.Maintain(ctx)
+	if err != nil || mResult.RetryAfter == nil {
+		return result, err
+	}
+	if result.Requeue && result.RequeueAfter == 0 {
+		// Already requeued immediately
+		return result, nil
+	}
+	if result.RequeueAfter == 0 || *mResult.RetryAfter < result.RequeueAfter {
+		return mResult.ReconcileResult(), nil
+	}
+	return result, nil
+}
@@ -107,12 +107,13 @@ func IsMoverLogDebug() bool {
 }
 
 func getPodLogs(ctx context.Context, logger logr.Logger, podName, podNamespace string,
-	lineFilter func(line string) *string) (string, error) {
+	sinceTime *metav1.Time, lineFilter func(line string) *string) (string, error) {
 	l := logger.WithValues("podName", podName, "podNamespace", podNamespace)
 
 	podLogOptions := &corev1.PodLogOptions{
 		//Container: containerName,
-		Follow: false,
+		Follow:    false,
+		SinceTime: sinceTime,
 	}
 
 	tailLines := GetMoverLogTailLines()
@@ -131,6 +132,14 @@ func getPodLogs(ctx context.Context, logger logr.Logger, podName, podNamespace s
 	return FilterLogs(stream, lineFilter)
 }
 
+// GetFilteredPodLogs returns the filtered logs of a mover Pod that is not
+// managed by a Job, such as the Pod of a long-running Deployment. Only the logs
+// since sinceTime are returned, if set.
+func GetFilteredPodLogs(ctx context.Context, logger logr.Logger, podName, podNamespace string,
+	sinceTime *metav1.Time, lineFilter func(line string) *string) (string, error) {
+	return getPodLogs(ctx, logger, podName, podNamespace, sinceTime, lineFilter)
+}
+
 // Appies lineFilter to each line
 func FilterLogs(reader io.Reader, lineFilter func(line string) *string) (string, error) {
 	if IsMoverLogDebug() {
@@ -202,7 +211,7 @@ func updateMoverStatusForJob(ctx context.Context, logger logr.Logger, moverStatu
 	}
 
 	l.Info("Getting logs for pod", "podName", pod.GetName(), "pod", pod)
-	filteredLogs, err := getPodLogs(ctx, l, pod.GetName(), jobNamespace, logLineFilter)
+	filteredLogs, err := getPodLogs(ctx, l, pod.GetName(), jobNamespace, nil, logLineFilter)
 	if err != nil {
 		l.Error(err, "Error getting logs from pod")
 	}
@@ -76,6 +76,8 @@ type SAHandlerVolSync struct {
 	roleBinding      *rbacv1.RoleBinding
 	PullSecretsMap   map[string]string
 	VolSyncNamespace string
+	// Rules granted to the mover in addition to the SCC of privileged movers
+	AdditionalRules []rbacv1.PolicyRule
 }
 
 var _ SAHandler = &SAHandlerVolSync{}
@@ -106,7 +108,7 @@ func NewSAHandler(c client.Client, owner metav1.Object, isSource,
 			Owner:            owner,
 			Privileged:       privileged,
 			PullSecretsMap:   getMoverImagePullSecretsAsMap(),
-			VolSyncNamespace: getVolSyncNamespace(),
+			VolSyncNamespace: GetVolSyncNamespace(),
 		}
 	}
 
@@ -260,17 +262,20 @@ func (d *SAHandlerVolSync) ensureRole(l logr.Logger) (bool, error) {
 			return err
 		}
 		SetOwnedByVolSync(d.role)
+		rules := []rbacv1.PolicyRule{}
 		if d.Privileged { // Only grant SCC to privileged movers
-			d.role.Rules = []rbacv1.PolicyRule{
-				{
-					APIGroups: []string{"security.openshift.io"},
-					Resources: []string{"securitycontextconstraints"},
-					// Must match the name of the SCC that is deployed w/ the operator
-					// config/openshift/mover_scc.yaml
-					ResourceNames: []string{SCCName},
-					Verbs:         []string{"use"},
-				},
-			}
+			rules = append(rules, rbacv1.PolicyRule{
+				APIGroups: []string{"security.openshift.io"},
+				Resources: []string{"securitycontextconstraints"},
+				// Must match the name of the SCC that is deployed w/ the operator
+				// config/openshift/mover_scc.yaml
+				ResourceNames: []string{SCCName},
+				Verbs:         []string{"use"},
+			})
+		}
+		rules = append(rules, d.AdditionalRules...)
+		if len(rules) > 0 || len(d.role.Rules) > 0 {
+			d.role.Rules = rules
 		}
 		return nil
 	})
@@ -20,10 +20,12 @@ package utils
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"hash/crc32"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -52,7 +54,8 @@ const (
 
 const VolSyncNamespaceEnvVar = "VOLSYNC_NAMESPACE"
 
-func getVolSyncNamespace() string {
+// GetVolSyncNamespace returns the namespace the operator runs in
+func GetVolSyncNamespace() string {
 	return os.Getenv(VolSyncNamespaceEnvVar)
 }
 
@@ -236,6 +239,107 @@ func AppendDebugMoverEnvVar(replicationSourceOrDestObj metav1.Object, envVars []
 	return envVars
 }
 
+// RsyncTuningEnvVars returns the env vars used by the rsync based mover scripts
+// to build the rsync options
+func RsyncTuningEnvVars(tuning *volsyncv1alpha1.RsyncTuningSpec) []corev1.EnvVar {
+	if tuning == nil {
+		return nil
+	}
+
+	envVars := []corev1.EnvVar{}
+	if tuning.BandwidthLimitKiB != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: "RSYNC_BWLIMIT",
+			Value: strconv.Itoa(int(*tuning.BandwidthLimitKiB))})
+	}
+	if tuning.Compression != nil && !*tuning.Compression {
+		envVars = append(envVars, corev1.EnvVar{Name: "RSYNC_COMPRESS", Value: "0"})
+	}
+	if tuning.CompressionLevel != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: "RSYNC_COMPRESS_LEVEL",
+			Value: strconv.Itoa(int(*tuning.CompressionLevel))})
+	}
+	if tuning.Checksum != nil && *tuning.Checksum {
+		envVars = append(envVars, corev1.EnvVar{Name: "RSYNC_CHECKSUM", Value: "1"})
+	}
+	if tuning.Partial != nil && *tuning.Partial {
+		envVars = append(envVars, corev1.EnvVar{Name: "RSYNC_PARTIAL", Value: "1"})
+	}
+	return envVars
+}
+
+const (
+	rsyncExcludeVolumeName = "rsync-excludes"
+	rsyncExcludeMountPath  = "/rsync-excludes"
+	rsyncExcludeFilename   = "excludes.txt"
+)
+
+// ValidateRsyncExcludes checks the exclude patterns of the rsync based movers
+// and that the exclude ConfigMap exists with the referenced key
+func ValidateRsyncExcludes(ctx context.Context, c client.Client, logger logr.Logger, namespace string,
+	patterns []string, excludeConfigMap *volsyncv1alpha1.ConfigMapKeySpec,
+) error {
+	for _, pattern := range patterns {
+		if len(strings.TrimSpace(pattern)) == 0 || strings.ContainsAny(pattern, "\r\n") {
+			err := fmt.Errorf("invalid exclude pattern: %q", pattern)
+			logger.Error(err, "Spec validation error")
+			return err
+		}
+	}
+	if excludeConfigMap == nil {
+		return nil
+	}
+	if len(excludeConfigMap.ConfigMapName) == 0 || len(excludeConfigMap.Key) == 0 {
+		err := errors.New("excludeConfigMap requires both configMapName and key")
+		logger.Error(err, "Spec validation error")
+		return err
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      excludeConfigMap.ConfigMapName,
+			Namespace: namespace,
+		},
+	}
+	logger = logger.WithValues("excludeConfigMap", client.ObjectKeyFromObject(configMap))
+	return GetAndValidateConfigMap(ctx, c, logger, configMap, excludeConfigMap.Key)
+}
+
+// UpdatePodSpecWithRsyncExcludes passes the exclude patterns to the rsync based
+// mover scripts and mounts the exclude ConfigMap, if any
+func UpdatePodSpecWithRsyncExcludes(podSpec *corev1.PodSpec, patterns []string,
+	excludeConfigMap *volsyncv1alpha1.ConfigMapKeySpec,
+) {
+	container := &podSpec.Containers[0]
+	if len(patterns) > 0 {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: "RSYNC_EXCLUDE_PATTERNS", Value: strings.Join(patterns, "\n"),
+		})
+	}
+	if excludeConfigMap == nil {
+		return
+	}
+
+	// Tell mover where to find the exclude file
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  "RSYNC_EXCLUDE_FROM",
+		Value: rsyncExcludeMountPath + "/" + rsyncExcludeFilename,
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      rsyncExcludeVolumeName,
+		MountPath: rsyncExcludeMountPath,
+	})
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: rsyncExcludeVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: excludeConfigMap.ConfigMapName},
+				Items: []corev1.KeyToPath{
+					{Key: excludeConfigMap.Key, Path: rsyncExcludeFilename},
+				},
+			},
+		},
+	})
+}
+
 // Updates to set the securityContext, podLabels on mover pod in the spec and resourceRequirements on the mover
 // containers based on what is set in the MoverConfig
 func UpdatePodTemplateSpecFromMoverConfig(podTemplateSpec *corev1.PodTemplateSpec,
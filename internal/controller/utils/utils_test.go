@@ -338,6 +338,81 @@ var _ = Describe("utils tests", func() {
 		})
 	})
 
+	Describe("RsyncTuningEnvVars", func() {
+		It("Should not set any env vars without tuning", func() {
+			Expect(utils.RsyncTuningEnvVars(nil)).To(BeEmpty())
+			Expect(utils.RsyncTuningEnvVars(&volsyncv1alpha1.RsyncTuningSpec{})).To(BeEmpty())
+		})
+
+		It("Should set env vars for the tuning options", func() {
+			envVars := utils.RsyncTuningEnvVars(&volsyncv1alpha1.RsyncTuningSpec{
+				BandwidthLimitKiB: ptr.To[int32](2048),
+				Compression:       ptr.To(false),
+				CompressionLevel:  ptr.To[int32](3),
+				Checksum:          ptr.To(true),
+				Partial:           ptr.To(true),
+			})
+			Expect(envVars).To(ConsistOf(
+				corev1.EnvVar{Name: "RSYNC_BWLIMIT", Value: "2048"},
+				corev1.EnvVar{Name: "RSYNC_COMPRESS", Value: "0"},
+				corev1.EnvVar{Name: "RSYNC_COMPRESS_LEVEL", Value: "3"},
+				corev1.EnvVar{Name: "RSYNC_CHECKSUM", Value: "1"},
+				corev1.EnvVar{Name: "RSYNC_PARTIAL", Value: "1"},
+			))
+		})
+
+		It("Should leave the defaults to the mover script", func() {
+			envVars := utils.RsyncTuningEnvVars(&volsyncv1alpha1.RsyncTuningSpec{
+				Compression: ptr.To(true),
+				Checksum:    ptr.To(false),
+				Partial:     ptr.To(false),
+			})
+			Expect(envVars).To(BeEmpty())
+		})
+	})
+
+	Describe("Rsync excludes", func() {
+		logger := zap.New(zap.UseDevMode(true), zap.WriteTo(GinkgoWriter))
+
+		It("Should reject empty and multi-line patterns", func() {
+			for _, pattern := range []string{" ", "a\nb"} {
+				Expect(utils.ValidateRsyncExcludes(ctx, nil, logger, "ns",
+					[]string{"cache/", pattern}, nil)).NotTo(Succeed())
+			}
+			Expect(utils.ValidateRsyncExcludes(ctx, nil, logger, "ns",
+				[]string{"cache/", "*.sock"}, nil)).To(Succeed())
+		})
+
+		It("Should require both the name and key of the ConfigMap", func() {
+			Expect(utils.ValidateRsyncExcludes(ctx, nil, logger, "ns", nil,
+				&volsyncv1alpha1.ConfigMapKeySpec{ConfigMapName: "excludes"})).NotTo(Succeed())
+		})
+
+		It("Should pass the patterns and mount the exclude file", func() {
+			podSpec := &corev1.PodSpec{Containers: []corev1.Container{{}}}
+			utils.UpdatePodSpecWithRsyncExcludes(podSpec, []string{"cache/", "*.sock"},
+				&volsyncv1alpha1.ConfigMapKeySpec{ConfigMapName: "excludes", Key: "exclude.txt"})
+			Expect(podSpec.Containers[0].Env).To(Equal([]corev1.EnvVar{
+				{Name: "RSYNC_EXCLUDE_PATTERNS", Value: "cache/\n*.sock"},
+				{Name: "RSYNC_EXCLUDE_FROM", Value: "/rsync-excludes/excludes.txt"},
+			}))
+			Expect(podSpec.Containers[0].VolumeMounts).To(Equal([]corev1.VolumeMount{
+				{Name: "rsync-excludes", MountPath: "/rsync-excludes"},
+			}))
+			Expect(podSpec.Volumes).To(HaveLen(1))
+			Expect(podSpec.Volumes[0].ConfigMap.Name).To(Equal("excludes"))
+			Expect(podSpec.Volumes[0].ConfigMap.Items).To(Equal([]corev1.KeyToPath{
+				{Key: "exclude.txt", Path: "excludes.txt"},
+			}))
+		})
+
+		It("Should not change the pod without excludes", func() {
+			podSpec := &corev1.PodSpec{Containers: []corev1.Container{{}}}
+			utils.UpdatePodSpecWithRsyncExcludes(podSpec, nil, nil)
+			Expect(podSpec).To(Equal(&corev1.PodSpec{Containers: []corev1.Container{{}}}))
+		})
+	})
+
 	Describe("UpdatePodTemplateSpecFromMoverConfig", func() {
 		When("no pod template spec", func() {
 			It("should not fail", func() {
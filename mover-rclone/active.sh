@@ -57,6 +57,28 @@ function error {
 [[ -n "${DIRECTION}" ]] || error 1 "DIRECTION must be defined"
 [[ -n "${PRIVILEGED_MOVER}" ]] || error 1 "PRIVILEGED_MOVER must be defined"
 
+# Operation used to transfer the data (sync, copy, move or bisync)
+RCLONE_MODE="${RCLONE_MODE:-sync}"
+case "${RCLONE_MODE}" in
+sync|copy|move|bisync)
+    ;;
+*)
+    error 1 "unknown value for RCLONE_MODE: ${RCLONE_MODE}"
+    ;;
+esac
+
+# Previous versions of files are kept on the remote under this directory, in a
+# subdirectory per synchronization
+VERSIONS_DIR=".volsync-versions"
+
+# Block devices are transferred as a single image file on the remote
+BLOCK_DEVICE="/dev/block"
+BLOCK_FILENAME="volsync-block.img"
+
+# Bisync keeps the listings from the previous run on the volume so that it can
+# tell which side changed
+BISYNC_WORKDIR=".volsync-bisync"
+
 # Some default options for COPY and SYNC - these will be used unless the user specifically sets them to override
 export RCLONE_TRANSFERS="${RCLONE_TRANSFERS:-10}"
 export RCLONE_CHECKSUM="${RCLONE_CHECKSUM:-1}"
@@ -68,28 +90,231 @@ RCLONE_FLAGS_SYNC=(--one-file-system --create-empty-src-dirs)
 # Flags for the permissions.facl copy
 RCLONE_FLAGS_COPY=(--one-file-system --create-empty-src-dirs --stats-one-line-date)
 
+# Flags for the transfer of a block device image
+RCLONE_FLAGS_BLOCK=()
+
 if [[ -n "${CUSTOM_CA}" ]]; then
     echo "Using custom CA."
     RCLONE_FLAGS_SYNC+=(--ca-cert "${CUSTOM_CA}")
     RCLONE_FLAGS_COPY+=(--ca-cert "${CUSTOM_CA}")
+    RCLONE_FLAGS_BLOCK+=(--ca-cert "${CUSTOM_CA}")
+fi
+
+REMOTE="${RCLONE_CONFIG_SECTION}:${RCLONE_DEST_PATH}"
+
+# With encryption, the data goes through a crypt remote layered over the
+# configured remote. The crypt remote is defined via env vars so the user's
+# rclone.conf doesn't need to contain it.
+if [[ -n "${CRYPT_PASSWORD}" ]]; then
+    echo "Using encryption."
+    export RCLONE_CONFIG_VOLSYNCCRYPT_TYPE=crypt
+    export RCLONE_CONFIG_VOLSYNCCRYPT_REMOTE="${REMOTE}"
+    RCLONE_CONFIG_VOLSYNCCRYPT_PASSWORD="$(printf '%s' "${CRYPT_PASSWORD}" | rclone obscure -)"
+    export RCLONE_CONFIG_VOLSYNCCRYPT_PASSWORD
+    if [[ -n "${CRYPT_SALT}" ]]; then
+        RCLONE_CONFIG_VOLSYNCCRYPT_PASSWORD2="$(printf '%s' "${CRYPT_SALT}" | rclone obscure -)"
+        export RCLONE_CONFIG_VOLSYNCCRYPT_PASSWORD2
+    fi
+    export RCLONE_CONFIG_VOLSYNCCRYPT_FILENAME_ENCRYPTION="${CRYPT_FILENAME_ENCRYPTION:-standard}"
+    export RCLONE_CONFIG_VOLSYNCCRYPT_DIRECTORY_NAME_ENCRYPTION="${CRYPT_DIRECTORY_NAME_ENCRYPTION:-true}"
+    REMOTE="volsynccrypt:"
+fi
+
+# Path of a file or directory within the remote
+function remote_path {
+    if [[ "${REMOTE}" == *: ]]; then
+        echo "${REMOTE}$1"
+    else
+        echo "${REMOTE%/}/$1"
+    fi
+}
+
+# Lists the version directories on the remote, newest first
+function list_versions {
+    rclone lsf --dirs-only "$(remote_path "${VERSIONS_DIR}")" 2> /dev/null | sed 's|/$||' | grep -E '^[0-9]{8}-[0-9]{6}$' | sort -r
+}
+
+# Removes the version directories beyond the retention count or age
+function prune_versions {
+    local cutoff=""
+    if [[ -n "${VERSIONS_MAX_AGE_DAYS}" ]]; then
+        cutoff="$(date -u -d "@$(( $(date +%s) - VERSIONS_MAX_AGE_DAYS * 86400 ))" +%Y%m%d-%H%M%S)"
+    fi
+    local -a versions
+    mapfile -t versions < <(list_versions)
+    local i
+    for i in "${!versions[@]}"; do
+        if [[ -n "${VERSIONS_KEEP}" && $i -ge ${VERSIONS_KEEP} ]] || [[ -n "${cutoff}" && "${versions[$i]}" < "${cutoff}" ]]; then
+            echo "Removing version ${versions[$i]}"
+            rclone purge "$(remote_path "${VERSIONS_DIR}/${versions[$i]}")"
+        fi
+    done
+}
+
+# The files replaced or deleted by a synchronization are saved in its version
+# directory, so the data as it was before a version is recovered by applying
+# the version directories from the newest back to the requested one.
+function restore_version {
+    local -a versions
+    mapfile -t versions < <(list_versions)
+    local found=0
+    for version in "${versions[@]}"; do
+        [[ "${version}" < "${RESTORE_VERSION}" ]] && break
+        echo "Applying version ${version}"
+        rclone copy "${RCLONE_FLAGS_SYNC[@]}" --filter-from "${FILTER_FILE}" "$(remote_path "${VERSIONS_DIR}/${version}")" "${MOUNT_PATH}" --log-level DEBUG
+        [[ "${version}" == "${RESTORE_VERSION}" ]] && found=1
+    done
+    [[ ${found} -eq 1 ]] || error 1 "version ${RESTORE_VERSION} not found"
+    echo "Restored version ${RESTORE_VERSION}"
+}
+
+# Rclone uses the first filter rule that matches a file, so the exclusions
+# VolSync requires are placed ahead of the user's filters. Everything goes into
+# a single filter file as the ordering of mixed filter flags is not defined.
+FILTER_FILE="/tmp/filter-rules.txt"
+function write_filter_file {
+    : > "${FILTER_FILE}"
+    for pattern in "$@"; do
+        echo "- ${pattern}" >> "${FILTER_FILE}"
+    done
+    if [[ -n "${RCLONE_FILTER_RULES}" ]]; then
+        echo "${RCLONE_FILTER_RULES}" >> "${FILTER_FILE}"
+    fi
+    if [[ -n "${RCLONE_FILTER_FROM}" ]]; then
+        cat "${RCLONE_FILTER_FROM}" >> "${FILTER_FILE}"
+        echo "" >> "${FILTER_FILE}"
+    fi
+}
+
+# Bidirectional sync between the volume and the remote. The first run has no
+# prior listings to compare against, so it needs a resync which merges both
+# sides.
+function do_bisync {
+    local workdir="${MOUNT_PATH}/${BISYNC_WORKDIR}"
+    local -a resync=()
+    if ! compgen -G "${workdir}/*.lst" > /dev/null; then
+        echo "No previous bisync listings found, performing resync"
+        resync=(--resync)
+    fi
+    write_filter_file "lost+found/**" "/${VERSIONS_DIR}/**" "/${BISYNC_WORKDIR}/**" /permissions.facl
+    rclone bisync "${RCLONE_FLAGS_SYNC[@]}" "${resync[@]}" --workdir "${workdir}" --filter-from "${FILTER_FILE}" "${MOUNT_PATH}" "${REMOTE}" --log-level DEBUG
+}
+
+# Compares the origin ($1) with the target ($2) after the transfer. Only the
+# files in the origin are checked when copying, since the target may hold
+# other files.
+# Through a crypt remote, rclone check can only compare the sizes, so
+# RCLONE_VERIFY_COMMAND is cryptcheck to compare the hashes. cryptcheck takes
+# the volume first and the crypt remote second, so when the remote is the
+# origin, the files to check are listed from the remote instead.
+function do_verify {
+    write_filter_file "lost+found/**" "/${VERSIONS_DIR}/**" "/${BISYNC_WORKDIR}/**" permissions.facl
+    local -a check_flags=(--filter-from "${FILTER_FILE}" --combined /tmp/check-combined.txt)
+    local -a check_args=("$1" "$2")
+    if [[ "${RCLONE_VERIFY}" == "size" ]]; then
+        check_flags+=(--size-only)
+    fi
+    if [[ "${RCLONE_VERIFY_COMMAND}" == "cryptcheck" && "$1" == "${REMOTE}" ]]; then
+        check_args=("$2" "$1")
+        if [[ "${RCLONE_MODE}" == "copy" ]]; then
+            rclone lsf -R --files-only --filter-from "${FILTER_FILE}" "${REMOTE}" > /tmp/check-files.txt
+            check_flags+=(--files-from /tmp/check-files.txt)
+        fi
+    elif [[ "${RCLONE_MODE}" == "copy" ]]; then
+        check_flags+=(--one-way)
+    fi
+    echo "Verifying transfer with rclone ${RCLONE_VERIFY_COMMAND:-check}"
+    if ! rclone "${RCLONE_VERIFY_COMMAND:-check}" "${check_flags[@]}" "${check_args[@]}" --log-level INFO; then
+        echo "Verification failed, $(grep -cv '^=' /tmp/check-combined.txt) differences found"
+        echo "(- only in ${check_args[0]}, + only in ${check_args[1]}, * contents differ, ! error reading)"
+        grep -v '^=' /tmp/check-combined.txt | head -n 50 | sed 's/^/Mismatch: /'
+        exit 1
+    fi
+    echo "Verification passed"
+}
+
+# When chunking, a chunker remote is layered over the remote to split the
+# image of a block device into multiple objects
+BLOCK_IMAGE="$(remote_path "${BLOCK_FILENAME}")"
+if [[ -n "${BLOCK_CHUNK_SIZE}" ]]; then
+    export RCLONE_CONFIG_VOLSYNCCHUNKER_TYPE=chunker
+    export RCLONE_CONFIG_VOLSYNCCHUNKER_REMOTE="${REMOTE}"
+    export RCLONE_CONFIG_VOLSYNCCHUNKER_CHUNK_SIZE="${BLOCK_CHUNK_SIZE}"
+    BLOCK_IMAGE="volsyncchunker:${BLOCK_FILENAME}"
 fi
 
+function upload_block {
+    local size
+    size="$(python3 -c 'import os, sys; print(os.lseek(os.open(sys.argv[1], os.O_RDONLY), 0, os.SEEK_END))' "${BLOCK_DEVICE}")"
+    echo "Uploading block device (${size} bytes)"
+    rclone rcat "${RCLONE_FLAGS_BLOCK[@]}" --size "${size}" "${BLOCK_IMAGE}" --log-level DEBUG < "${BLOCK_DEVICE}"
+}
+
+function download_block {
+    echo "Downloading block device"
+    rclone cat "${RCLONE_FLAGS_BLOCK[@]}" "${BLOCK_IMAGE}" --log-level DEBUG | dd of="${BLOCK_DEVICE}" bs=4M conv=fsync status=none
+}
+
 START_TIME=$SECONDS
-case "${DIRECTION}" in
-source)
-    find "${MOUNT_PATH}" -path "${MOUNT_PATH}/lost+found" -prune -o -print | getfacl -P - > /tmp/permissions.facl
-    rclone sync "${RCLONE_FLAGS_SYNC[@]}" --exclude "lost+found/**" "${MOUNT_PATH}" "${RCLONE_CONFIG_SECTION}:${RCLONE_DEST_PATH}" --log-level DEBUG
-    rclone copy "${RCLONE_FLAGS_COPY[@]}" --include permissions.facl /tmp "${RCLONE_CONFIG_SECTION}:${RCLONE_DEST_PATH}" --log-level DEBUG
-    ;;
-destination)
-    rclone sync "${RCLONE_FLAGS_SYNC[@]}" --exclude "lost+found/**" --exclude permissions.facl "${RCLONE_CONFIG_SECTION}:${RCLONE_DEST_PATH}" "${MOUNT_PATH}" --log-level DEBUG
-    rclone copy "${RCLONE_FLAGS_COPY[@]}" --include permissions.facl "${RCLONE_CONFIG_SECTION}:${RCLONE_DEST_PATH}" /tmp --log-level DEBUG
-    stat /tmp/permissions.facl
-    setfacl --restore=/tmp/permissions.facl || true
-    ;;
-*)
-    error 1 "unknown value for DIRECTION: ${DIRECTION}"
-    ;;
-esac
-sync -f "${MOUNT_PATH}"
+if [[ -b "${BLOCK_DEVICE}" ]]; then
+    case "${DIRECTION}" in
+    source)
+        upload_block
+        ;;
+    destination)
+        download_block
+        ;;
+    *)
+        error 1 "unknown value for DIRECTION: ${DIRECTION}"
+        ;;
+    esac
+else
+    case "${DIRECTION}" in
+    source)
+        find "${MOUNT_PATH}" -path "${MOUNT_PATH}/lost+found" -prune -o -print | getfacl -P - > /tmp/permissions.facl
+        if [[ "${RCLONE_MODE}" == "bisync" ]]; then
+            do_bisync
+        else
+            declare -a VERSION_FLAGS=()
+            if [[ "${RCLONE_VERSIONING}" -eq 1 ]]; then
+                VERSION="$(date -u +%Y%m%d-%H%M%S)"
+                echo "Saving replaced and deleted files in version ${VERSION}"
+                VERSION_FLAGS=(--backup-dir "$(remote_path "${VERSIONS_DIR}/${VERSION}")")
+            fi
+            # Versions are kept within the remote path, so they must be excluded
+            # to not be deleted
+            write_filter_file "lost+found/**" "/${VERSIONS_DIR}/**"
+            rclone "${RCLONE_MODE}" "${RCLONE_FLAGS_SYNC[@]}" "${VERSION_FLAGS[@]}" --filter-from "${FILTER_FILE}" "${MOUNT_PATH}" "${REMOTE}" --log-level DEBUG
+        fi
+        rclone copy "${RCLONE_FLAGS_COPY[@]}" --include permissions.facl /tmp "${REMOTE}" --log-level DEBUG
+        if [[ -n "${RCLONE_VERIFY}" ]]; then
+            do_verify "${MOUNT_PATH}" "${REMOTE}"
+        fi
+        if [[ "${RCLONE_VERSIONING}" -eq 1 ]]; then
+            prune_versions
+        fi
+        ;;
+    destination)
+        # Moving or bisyncing would delete or modify the data in the remote
+        if [[ "${RCLONE_MODE}" == "move" || "${RCLONE_MODE}" == "bisync" ]]; then
+            error 1 "RCLONE_MODE ${RCLONE_MODE} is not supported on a destination"
+        fi
+        write_filter_file "lost+found/**" "/${VERSIONS_DIR}/**" permissions.facl
+        rclone "${RCLONE_MODE}" "${RCLONE_FLAGS_SYNC[@]}" --filter-from "${FILTER_FILE}" "${REMOTE}" "${MOUNT_PATH}" --log-level DEBUG
+        if [[ -n "${RESTORE_VERSION}" ]]; then
+            restore_version
+        fi
+        rclone copy "${RCLONE_FLAGS_COPY[@]}" --include permissions.facl "${REMOTE}" /tmp --log-level DEBUG
+        stat /tmp/permissions.facl
+        setfacl --restore=/tmp/permissions.facl || true
+        if [[ -n "${RCLONE_VERIFY}" ]]; then
+            do_verify "${REMOTE}" "${MOUNT_PATH}"
+        fi
+        ;;
+    *)
+        error 1 "unknown value for DIRECTION: ${DIRECTION}"
+        ;;
+    esac
+    sync -f "${MOUNT_PATH}"
+fi
 echo "Rclone completed in $(( SECONDS - START_TIME ))s"
@@ -55,11 +55,26 @@ if [[ -n "${CUSTOM_CA}" ]]; then
     echo "Using custom CA."
     RESTIC+=(--cacert "${CUSTOM_CA}")
 fi
+if [[ -n "${GLOBAL_OPTIONS}" ]]; then
+    echo "GLOBAL_OPTIONS: ${GLOBAL_OPTIONS}"
+    # Split the options into separate words
+    #shellcheck disable=SC2206
+    RESTIC+=(${GLOBAL_OPTIONS})
+fi
+if [[ -n "${RETRY_LOCK}" ]]; then
+    # Wait for a lock held by a backup or maintenance of the repository
+    RESTIC+=(--retry-lock "${RETRY_LOCK}")
+fi
 
 "${RESTIC[@]}" version
 
 # Force the associated backup host name to be "volsync"
 RESTIC_HOST="volsync"
+# Block volumes are presented to the mover as a device at this path
+BLOCK_DEVICE="/dev/block"
+# The contents of a block device are stored in the repository as a single file
+# with this name
+BLOCK_FILENAME="volsync-block.img"
 # Make restic output progress reports every 10s
 export RESTIC_PROGRESS_FPS=0.1
 
@@ -88,6 +103,7 @@ function check_contents {
 }
 
 # Ensure the repo has been initialized
+# Any arguments are passed on to restic init
 function ensure_initialized {
     echo "=== Check for dir initialized ==="
     # check for restic config and capture rc
@@ -121,7 +137,7 @@ function ensure_initialized {
         # the following cmd `restic init` will also fail)
         if [[ $output =~ .*(Is there a repository at the following location).* ]]; then
             echo "=== Initialize Dir ==="
-            "${RESTIC[@]}" init
+            "${RESTIC[@]}" init "$@"
         else
             cat "$outfile"
             error 3 "failure checking existence of repository"
@@ -130,7 +146,7 @@ function ensure_initialized {
     10)
         # rc = 10  Repository does not exist (since restic 0.17.0)
         echo "=== Initialize Dir ==="
-        "${RESTIC[@]}" init
+        "${RESTIC[@]}" init "$@"
         ;;
     11)
         # rc = 11  Failed to lock repository (since restic 0.17.0)
@@ -151,11 +167,22 @@ function ensure_initialized {
     rm -f "$outfile"
 }
 
+function is_block_volume {
+    test -b "${BLOCK_DEVICE}"
+}
+
 function do_backup {
     echo "=== Starting backup ==="
-    pushd "${DATA_DIR}"
-    "${RESTIC[@]}" backup --host "${RESTIC_HOST}" --exclude='lost+found' .
-    popd
+    if is_block_volume; then
+        echo "Backing up block device"
+        #shellcheck disable=SC2086
+        "${RESTIC[@]}" backup --host "${RESTIC_HOST}" ${BACKUP_OPTIONS} --stdin --stdin-filename "${BLOCK_FILENAME}" < "${BLOCK_DEVICE}"
+    else
+        pushd "${DATA_DIR}"
+        #shellcheck disable=SC2086
+        "${RESTIC[@]}" backup --host "${RESTIC_HOST}" ${BACKUP_OPTIONS} --exclude='lost+found' .
+        popd
+    fi
 }
 
 function do_forget {
@@ -189,6 +216,126 @@ function do_unlock {
 function do_prune {
     echo "=== Starting prune ==="
     "${RESTIC[@]}" prune
+    if [[ -n ${COPY_TO_RESTIC_REPOSITORY} ]]; then
+        (
+            use_copy_to_repository
+            echo "=== Starting prune of copy repository ==="
+            "${RESTIC[@]}" prune
+        )
+    fi
+}
+
+function do_check {
+    echo "=== Starting check ==="
+    "${RESTIC[@]}" check
+    if [[ -n ${COPY_TO_RESTIC_REPOSITORY} ]]; then
+        (
+            use_copy_to_repository
+            echo "=== Starting check of copy repository ==="
+            "${RESTIC[@]}" check
+        )
+    fi
+}
+
+#######################################
+# Exports the env vars of the secondary
+# (copyTo) repository without their prefix.
+# Should be called from within a subshell.
+# Globals:
+#   COPY_TO_*
+# Arguments:
+#   None
+#######################################
+function export_copy_to_env() {
+    local var
+    for var in $(compgen -v COPY_TO_); do
+        export "${var#COPY_TO_}=${!var}"
+    done
+}
+
+#######################################
+# Switches the restic env vars over to the
+# secondary (copyTo) repository. The env vars
+# from the primary repository Secret are unset
+# first, so that none of them are used for the
+# secondary repository.
+# Should be called from within a subshell.
+# Globals:
+#   REPOSITORY_ENV_VARS
+#   COPY_TO_*
+# Arguments:
+#   None
+#######################################
+function use_copy_to_repository() {
+    local var
+    for var in ${REPOSITORY_ENV_VARS}; do
+        unset "${var}"
+    done
+    export_copy_to_env
+}
+
+#######################################
+# Copies the latest snapshot into the
+# secondary (copyTo) repository and applies
+# the forget policy of that repository.
+# The primary repository is opened with the
+# --from-* options of restic, and its backend
+# credentials are passed with the RESTIC_FROM_
+# prefix so that they don't conflict with
+# those of the secondary repository.
+# The result is written to the termination log
+# so it can be picked up by the operator.
+# Globals:
+#   RESTIC_HOST
+#   RESTIC_REPOSITORY
+#   RESTIC_PASSWORD
+#   REPOSITORY_ENV_VARS
+#   COPY_TO_*
+# Arguments:
+#   None
+#######################################
+function do_copy {
+    echo "=== Starting copy ==="
+    check_var_defined COPY_TO_RESTIC_REPOSITORY
+    check_var_defined COPY_TO_RESTIC_PASSWORD
+    local snapshot_id
+    snapshot_id=$("${RESTIC[@]}" snapshots --host "${RESTIC_HOST}" --json latest | grep -o '"id":"[0-9a-f]*"' | head -n 1 | cut -d'"' -f4)
+    snapshot_id="${snapshot_id:0:8}"
+    if [[ -z ${snapshot_id} ]]; then
+        error 3 "no snapshot to copy"
+    fi
+    echo "Copying snapshot ${snapshot_id}"
+    local -i rc=0
+    # The subshell must not be a condition for set -e to apply within it
+    set +e
+    (
+        set -e
+        export RESTIC_FROM_REPOSITORY="${RESTIC_REPOSITORY}"
+        export RESTIC_FROM_PASSWORD="${RESTIC_PASSWORD}"
+        for var in ${REPOSITORY_ENV_VARS}; do
+            # rclone env vars are named after the remote they configure
+            if [[ ${var} != RCLONE_* ]]; then
+                export "RESTIC_FROM_${var}=${!var}"
+                unset "${var}"
+            fi
+        done
+        export_copy_to_env
+        # Use the same chunker parameters as the primary repository so that
+        # data is deduplicated across the copy
+        ensure_initialized --copy-chunker-params
+        "${RESTIC[@]}" copy "${snapshot_id}"
+        do_forget
+    )
+    rc=$?
+    set -e
+    local result="Successful"
+    if [[ ${rc} -ne 0 ]]; then
+        result="Failed"
+    fi
+    printf '{"snapshot":"%s","result":"%s"}' "${snapshot_id}" "${result}" > /dev/termination-log
+    if [[ ${rc} -ne 0 ]]; then
+        error 3 "failure copying snapshot ${snapshot_id}"
+    fi
 }
 
 #######################################
@@ -259,10 +406,18 @@ function reverse_array() {
 # Globals:
 #   SELECT_PREVIOUS
 #   RESTORE_AS_OF
+#   BLOCK_FILENAME
 # Arguments:
 #   None
 ################################################################
 function select_restic_snapshot_to_restore() {
+    # only consider snapshots of the same type (filesystem or block) as the
+    # volume being restored to
+    local snapshot_path="/data"
+    if is_block_volume; then
+        snapshot_path="/${BLOCK_FILENAME}"
+    fi
+
     # list of epochs
     declare -a epochs
     # create an associative array that maps numeric epoch to the restic snapshot IDs
@@ -281,7 +436,7 @@ function select_restic_snapshot_to_restore() {
 
     # go through the timestamps received from restic
     IFS=$'\n'
-    for line in $(echo -e "${restic_snapshots}" | grep /data | awk '{print $1 "\t" $2 " " $3}'); do
+    for line in $(echo -e "${restic_snapshots}" | grep "${snapshot_path}" | awk '{print $1 "\t" $2 " " $3}'); do
         # extract the proper variables
         snapshot_id=$(echo -e "${line}" | cut -d$'\t' -f1)
         snapshot_ts=$(echo -e "${line}" | cut -d$'\t' -f2)
@@ -320,6 +475,40 @@ function select_restic_snapshot_to_restore() {
 }
 
 
+#######################################
+# Reports what restoring the snapshot would change
+# on the volume, without modifying any data.
+# The counts are written to the termination log so
+# they can be picked up by the operator.
+# Globals:
+#   DATA_DIR
+#   RESTIC_HOST
+#   RESTORE_OPTIONS
+# Arguments:
+#   The id of the snapshot to preview
+#######################################
+function do_restore_preview {
+    local snapshot_id="$1"
+    echo "=== Starting restore preview ==="
+    local outfile
+    outfile=$(mktemp -q)
+    pushd "${DATA_DIR}"
+    # Running this cmd can be finicky with spaces, do not put quotes around ${RESTORE_OPTIONS}
+    #shellcheck disable=SC2086
+    "${RESTIC[@]}" restore "${snapshot_id}" -t . --host "${RESTIC_HOST}" --dry-run --verbose=2 ${RESTORE_OPTIONS} > "${outfile}"
+    popd
+    # Only files are reported with a size, directories are not counted as
+    # added or changed
+    local -i to_add to_change to_delete
+    to_add=$(grep -c -E '^restored +.* with size ' "${outfile}" || true)
+    to_change=$(grep -c -E '^updated +.* with size ' "${outfile}" || true)
+    to_delete=$(grep -c -E '^deleted +' "${outfile}" || true)
+    rm -f "${outfile}"
+    echo "Restore preview of snapshot ${snapshot_id}: ${to_add} to add, ${to_change} to change, ${to_delete} to delete"
+    printf '{"snapshot":"%s","filesToAdd":%d,"filesToChange":%d,"filesToDelete":%d}' \
+        "${snapshot_id}" "${to_add}" "${to_change}" "${to_delete}" > /dev/termination-log
+}
+
 #######################################
 # Restores from a selected snapshot if
 # RESTORE_AS_OF is provided, otherwise
@@ -328,6 +517,8 @@ function select_restic_snapshot_to_restore() {
 #   RESTORE_AS_OF
 #   DATA_DIR
 #   RESTIC_HOST
+#   BLOCK_DEVICE
+#   BLOCK_FILENAME
 # Arguments:
 #   None
 #######################################
@@ -339,6 +530,16 @@ function do_restore {
     if [[ -z ${snapshot_id} ]]; then
         echo "No eligible snapshots found"
         echo "=== No data will be restored ==="
+    elif is_block_volume && [[ -n ${RESTORE_PREVIEW} ]]; then
+        # The controller rejects this, fail rather than report an empty preview
+        error 3 "restore preview is not available for block volumes"
+    elif is_block_volume; then
+        echo "Selected restic snapshot with id: ${snapshot_id}"
+        echo "Restoring block device from snapshot ${snapshot_id}"
+        "${RESTIC[@]}" dump "${snapshot_id}" "/${BLOCK_FILENAME}" > "${BLOCK_DEVICE}"
+    elif [[ -n ${RESTORE_PREVIEW} ]]; then
+        echo "Selected restic snapshot with id: ${snapshot_id}"
+        do_restore_preview "${snapshot_id}"
     else
         if [[ -n ${RESTORE_OPTIONS} ]]; then
           echo "RESTORE_OPTIONS: ${RESTORE_OPTIONS}"
@@ -369,18 +570,30 @@ for op in "$@"; do
             do_unlock
             ;;
         "backup")
-            check_contents
+            if ! is_block_volume; then
+                check_contents
+            fi
             ensure_initialized
             do_backup
             do_forget
             ;;
+        "copy")
+            do_copy
+            ;;
         "prune")
             do_prune
             ;;
+        "check")
+            do_check
+            ;;
         "restore")
             ensure_initialized
             do_restore
-            sync -f "${DATA_DIR}"
+            if is_block_volume; then
+                sync -f "${BLOCK_DEVICE}"
+            else
+                sync -f "${DATA_DIR}"
+            fi
             ;;
         *)
             error 2 "unknown operation: $op"
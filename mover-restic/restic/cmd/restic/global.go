@@ -80,6 +80,10 @@ type GlobalOptions struct {
 	stdout   io.Writer
 	stderr   io.Writer
 
+	// backendEnvPrefix is prepended to the names of the environment variables
+	// that the backend configuration is read from
+	backendEnvPrefix string
+
 	backends                              *location.Registry
 	backendTestHook, backendInnerTestHook backendWrapper
 
@@ -583,10 +587,10 @@ func OpenRepository(ctx context.Context, opts GlobalOptions) (*repository.Reposi
 	return s, nil
 }
 
-func parseConfig(loc location.Location, opts options.Options) (interface{}, error) {
+func parseConfig(loc location.Location, opts options.Options, envPrefix string) (interface{}, error) {
 	cfg := loc.Config
 	if cfg, ok := cfg.(backend.ApplyEnvironmenter); ok {
-		cfg.ApplyEnvironment("")
+		cfg.ApplyEnvironment(envPrefix)
 	}
 
 	// only apply options for a particular backend here
@@ -606,7 +610,7 @@ func innerOpen(ctx context.Context, s string, gopts GlobalOptions, opts options.
 		return nil, errors.Fatalf("parsing repository location failed: %v", err)
 	}
 
-	cfg, err := parseConfig(loc, opts)
+	cfg, err := parseConfig(loc, opts, gopts.backendEnvPrefix)
 	if err != nil {
 		return nil, err
 	}
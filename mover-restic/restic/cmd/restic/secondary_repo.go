@@ -88,6 +88,8 @@ func fillSecondaryGlobalOpts(ctx context.Context, opts secondaryRepoOptions, gop
 		dstGopts.PasswordCommand = opts.PasswordCommand
 		dstGopts.KeyHint = opts.KeyHint
 		dstGopts.InsecureNoPassword = opts.InsecureNoPassword
+		// backend credentials of the source repository can be set separately
+		dstGopts.backendEnvPrefix = "RESTIC_FROM_"
 
 		pwdEnv = "RESTIC_FROM_PASSWORD"
 		repoPrefix = "source"
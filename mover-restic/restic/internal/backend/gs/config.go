@@ -20,6 +20,10 @@ type Config struct {
 
 	Connections uint   `option:"connections" help:"set a limit for the number of concurrent connections (default: 5)"`
 	Region      string `option:"region" help:"region to create the bucket in (default: us)"`
+
+	// credentialsFile is only read from prefixed env vars, the application
+	// default credentials are used otherwise
+	credentialsFile string
 }
 
 // NewConfig returns a new Config with the default values filled in.
@@ -66,4 +70,7 @@ func (cfg *Config) ApplyEnvironment(prefix string) {
 	if cfg.ProjectID == "" {
 		cfg.ProjectID = os.Getenv(prefix + "GOOGLE_PROJECT_ID")
 	}
+	if prefix != "" && cfg.credentialsFile == "" {
+		cfg.credentialsFile = os.Getenv(prefix + "GOOGLE_APPLICATION_CREDENTIALS")
+	}
 }
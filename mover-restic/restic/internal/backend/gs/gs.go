@@ -53,7 +53,7 @@ func NewFactory() location.Factory {
 	return location.NewHTTPBackendFactory("gs", ParseConfig, location.NoPassword, Create, Open)
 }
 
-func getStorageClient(rt http.RoundTripper) (*storage.Client, error) {
+func getStorageClient(rt http.RoundTripper, credentialsFile string) (*storage.Client, error) {
 	// create a new HTTP client
 	httpClient := &http.Client{
 		Transport: rt,
@@ -68,6 +68,16 @@ func getStorageClient(rt http.RoundTripper) (*storage.Client, error) {
 			AccessToken: token,
 			TokenType:   "Bearer",
 		})
+	} else if credentialsFile != "" {
+		data, err := os.ReadFile(credentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		creds, err := google.CredentialsFromJSON(ctx, data, storage.ScopeReadWrite)
+		if err != nil {
+			return nil, err
+		}
+		ts = creds.TokenSource
 	} else {
 		var err error
 		ts, err = google.DefaultTokenSource(ctx, storage.ScopeReadWrite)
@@ -99,7 +109,7 @@ const defaultListMaxItems = 1000
 func open(cfg Config, rt http.RoundTripper) (*Backend, error) {
 	debug.Log("open, config %#v", cfg)
 
-	gcsClient, err := getStorageClient(rt)
+	gcsClient, err := getStorageClient(rt, cfg.credentialsFile)
 	if err != nil {
 		return nil, errors.Wrap(err, "getStorageClient")
 	}
@@ -35,6 +35,10 @@ type Config struct {
 	BucketLookup        string `option:"bucket-lookup" help:"bucket lookup style: 'auto', 'dns', or 'path'"`
 	ListObjectsV1       bool   `option:"list-objects-v1" help:"use deprecated V1 api for ListObjects calls"`
 	UnsafeAnonymousAuth bool   `option:"unsafe-anonymous-auth" help:"use anonymous authentication"`
+
+	// envPrefix is the prefix of the environment variables the credentials
+	// were read from
+	envPrefix string
 }
 
 // NewConfig returns a new Config with the default values filled in.
@@ -109,6 +113,7 @@ var _ backend.ApplyEnvironmenter = &Config{}
 
 // ApplyEnvironment saves values from the environment to the config.
 func (cfg *Config) ApplyEnvironment(prefix string) {
+	cfg.envPrefix = prefix
 	if cfg.KeyID == "" {
 		cfg.KeyID = os.Getenv(prefix + "AWS_ACCESS_KEY_ID")
 	}
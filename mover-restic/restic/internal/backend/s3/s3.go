@@ -120,19 +120,27 @@ func getCredentials(cfg Config, tr http.RoundTripper) (*credentials.Credentials,
 	//  - IAM profile based credentials. (performs an HTTP
 	//    call to a pre-defined endpoint, only valid inside
 	//    configured ec2 instances)
-	creds := credentials.NewChainCredentials([]credentials.Provider{
-		&credentials.EnvAWS{},
-		&credentials.Static{
-			Value: credentials.Value{
-				AccessKeyID:     cfg.KeyID,
-				SecretAccessKey: cfg.Secret.Unwrap(),
-			},
+	static := &credentials.Static{
+		Value: credentials.Value{
+			AccessKeyID:     cfg.KeyID,
+			SecretAccessKey: cfg.Secret.Unwrap(),
 		},
+	}
+	providers := []credentials.Provider{
+		&credentials.EnvAWS{},
+		static,
 		&credentials.EnvMinio{},
 		&credentials.FileAWSCredentials{},
 		&credentials.FileMinioClient{},
 		&credentials.IAM{},
-	})
+	}
+	if cfg.envPrefix != "" {
+		// The prefixed env vars belong to this repository only, so they take
+		// precedence over the unprefixed AWS env vars
+		static.SessionToken = os.Getenv(cfg.envPrefix + "AWS_SESSION_TOKEN")
+		providers[0], providers[1] = providers[1], providers[0]
+	}
+	creds := credentials.NewChainCredentials(providers)
 	client := &http.Client{Transport: tr}
 
 	c, err := creds.GetWithContext(&credentials.CredContext{Client: client})
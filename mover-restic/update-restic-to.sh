@@ -83,6 +83,9 @@ cd ..
 cd restic
 # Remove sha256-simd library
 find . -name '*.go' -exec sed -ri 's|github.com/minio/sha256-simd|crypto/sha256|' {} \;
+# Read the backend credentials of the source repository of restic copy from
+# RESTIC_FROM_ prefixed env vars
+patch -p1 < ../patches/restic-from-repo-credentials.patch
 # Override restic's imports of minio-go to use our patched sources
 go mod edit --replace github.com/minio/minio-go/v7=../minio-go
 go mod tidy
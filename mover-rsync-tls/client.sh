@@ -63,9 +63,45 @@ function stop_stunnel() {
     kill -TERM "$(<"$STUNNEL_PID_FILE")"
 }
 
-if [[ ! -r $PSK_FILE ]]; then
-    echo "ERROR: Pre-shared key not found - $PSK_FILE"
-    exit 1
+if [[ -n "${TLS_CERT_FILE}" ]]; then
+    ## Certificate-based mutual TLS, the server must present a certificate
+    ## signed by the CA
+    for f in "$TLS_CERT_FILE" "$TLS_KEY_FILE" "$TLS_CA_FILE"; do
+        if [[ ! -r $f ]]; then
+            echo "ERROR: TLS certificate file not found - $f"
+            exit 1
+        fi
+    done
+    TLS_AUTH="cert = $TLS_CERT_FILE
+key = $TLS_KEY_FILE
+CAfile = $TLS_CA_FILE
+verifyChain = yes
+sslVersionMin = ${SSL_VERSION_MIN:-TLSv1.3}"
+    if [[ -n ${TLS_CHECK_HOST} ]]; then
+        TLS_AUTH+=$'\n'"checkHost = ${TLS_CHECK_HOST}"
+    fi
+    if [[ -n ${TLS_CIPHERS} ]]; then
+        TLS_AUTH+=$'\n'"ciphers = ${TLS_CIPHERS}"
+    fi
+    if [[ -n ${TLS_CIPHERSUITES} ]]; then
+        TLS_AUTH+=$'\n'"ciphersuites = ${TLS_CIPHERSUITES}"
+    fi
+else
+    if [[ ! -r $PSK_FILE ]]; then
+        echo "ERROR: Pre-shared key not found - $PSK_FILE"
+        exit 1
+    fi
+    # The key file may hold several identities during a key rotation. Use the
+    # requested one, or the first one in the file.
+    PSK_IDENTITY=${PSK_IDENTITY:-$(head -n1 "$PSK_FILE" | cut -d: -f1)}
+    TLS_AUTH="ciphers = PSK
+PSKsecrets = $PSK_FILE
+PSKidentity = $PSK_IDENTITY"
+fi
+# Routers and Gateways pass the connection through to the destination based on
+# the server name
+if [[ -n ${TLS_SERVER_NAME} ]]; then
+    TLS_AUTH+=$'\n'"sni = ${TLS_SERVER_NAME}"
 fi
 
 if [[ ! -d $SOURCE ]] && ! test -b $BLOCK_SOURCE; then
@@ -89,8 +125,7 @@ socket = r:TCP_KEEPIDLE=180
 syslog = no
 
 [rsync]
-ciphers = PSK
-PSKsecrets = $PSK_FILE
+$TLS_AUTH
 ; Port to listen for incoming connection from rsync
 accept = 127.0.0.1:$STUNNEL_LISTEN_PORT
 ; We are the client
@@ -117,8 +152,7 @@ socket = r:TCP_KEEPIDLE=180
 syslog = no
 
 [diskrsync]
-ciphers = PSK
-PSKsecrets = $PSK_FILE
+$TLS_AUTH
 ; Port to listen for incoming connection from diskrsync
 accept = 127.0.0.1:$STUNNEL_LISTEN_PORT
 ; We are the client
@@ -133,6 +167,43 @@ stunnel -version "$STUNNEL_CONF"
 stunnel "$STUNNEL_CONF"
 trap stop_stunnel EXIT
 
+# shellcheck source=mover-rsync/rsync-opts.sh
+source /mover-rsync/rsync-opts.sh
+
+DISKRSYNC_OPTS=()
+if [[ $DISKRSYNC_RESUME -eq 1 ]]; then
+    DISKRSYNC_OPTS+=("--resume")
+fi
+if [[ -n ${DISKRSYNC_COMPRESSION} ]]; then
+    DISKRSYNC_OPTS+=("--compression" "${DISKRSYNC_COMPRESSION}")
+fi
+if [[ -n ${DISKRSYNC_COMPRESSION_LEVEL} ]]; then
+    DISKRSYNC_OPTS+=("--compression-level" "${DISKRSYNC_COMPRESSION_LEVEL}")
+fi
+if [[ -n ${DISKRSYNC_STREAMS} ]]; then
+    DISKRSYNC_OPTS+=("--streams" "${DISKRSYNC_STREAMS}")
+fi
+if [[ -n ${DISKRSYNC_CHECKSUM} ]]; then
+    DISKRSYNC_OPTS+=("--checksum" "${DISKRSYNC_CHECKSUM}")
+fi
+if [[ -n ${PSK_IDENTITY} ]]; then
+    # Let the destination know which key was used
+    DISKRSYNC_OPTS+=("--psk-identity" "${PSK_IDENTITY}")
+fi
+if [[ -n ${SNAPSHOT_METADATA_ADDRESS} ]]; then
+    # Only send the blocks that changed since the base snapshot
+    DISKRSYNC_OPTS+=("--snapshot-metadata-address" "${SNAPSHOT_METADATA_ADDRESS}"
+        "--snapshot-metadata-token-file" "${SNAPSHOT_METADATA_TOKEN_FILE}"
+        "--namespace" "${SNAPSHOT_NAMESPACE}"
+        "--base-snapshot-id" "${BASE_SNAPSHOT_ID}"
+        "--target-snapshot" "${TARGET_SNAPSHOT_NAME}")
+    if [[ -n ${SNAPSHOT_METADATA_CA_CERT} ]]; then
+        SNAPSHOT_METADATA_CA_FILE=/tmp/snapshot-metadata-ca.crt
+        echo "${SNAPSHOT_METADATA_CA_CERT}" > "$SNAPSHOT_METADATA_CA_FILE"
+        DISKRSYNC_OPTS+=("--snapshot-metadata-ca-file" "$SNAPSHOT_METADATA_CA_FILE")
+    fi
+fi
+
 # Sync files
 START_TIME=$SECONDS
 MAX_RETRIES=5
@@ -145,15 +216,15 @@ echo "Syncing data to ${DESTINATION_ADDRESS}:${DESTINATION_PORT} ..."
 while [[ $rc -ne 0 && $RETRY -lt $MAX_RETRIES ]]; do
     RETRY=$(( RETRY + 1 ))
     if test -b $BLOCK_SOURCE; then
-      echo "calling diskrsync-tcp $BLOCK_SOURCE --source --target-address 127.0.0.1 --port $STUNNEL_LISTEN_PORT"
-      /diskrsync-tcp $BLOCK_SOURCE --source --target-address 127.0.0.1 --port $STUNNEL_LISTEN_PORT
+      echo "calling diskrsync-tcp $BLOCK_SOURCE --source --target-address 127.0.0.1 --port $STUNNEL_LISTEN_PORT ${DISKRSYNC_OPTS[*]}"
+      /diskrsync-tcp $BLOCK_SOURCE --source --target-address 127.0.0.1 --port $STUNNEL_LISTEN_PORT "${DISKRSYNC_OPTS[@]}"
       rc=$?
     else
         # Find all files/dirs at root of pvc, prepend / to each (rsync will use SOURCE as the base dir for these files)
         find "${SOURCE}" -mindepth 1 -maxdepth 1 -printf '/%P\n' > /tmp/filelist.txt
         if [[ -s /tmp/filelist.txt ]]; then
             # 1st run preserves as much as possible, but excludes the root directory
-            rsync -aAhHSxz -r --exclude=lost+found --itemize-changes --info=stats2,misc2 --files-from=/tmp/filelist.txt ${SOURCE}/ rsync://127.0.0.1:$STUNNEL_LISTEN_PORT/data | tee /tmp/rsync-full.log
+            rsync "${RSYNC_OPTS[@]}" "${RSYNC_EXCLUDE_OPTS[@]}" -r --exclude=lost+found --itemize-changes --info=stats2,misc2 --files-from=/tmp/filelist.txt ${SOURCE}/ rsync://127.0.0.1:$STUNNEL_LISTEN_PORT/data | tee /tmp/rsync-full.log
             rc_a=$?
 
             if [[ $rc_a -eq 0 ]]; then
@@ -174,7 +245,7 @@ while [[ $rc -ne 0 && $RETRY -lt $MAX_RETRIES ]]; do
         # To delete extra files, must sync at the directory-level, but need to avoid
         # trying to modify the directory itself. This pass will only delete files
         # that exist on the destination but not on the source, not make updates.
-        rsync -rx --exclude=lost+found --ignore-existing --ignore-non-existing --delete --itemize-changes --info=stats2,misc2 ${SOURCE}/ rsync://127.0.0.1:$STUNNEL_LISTEN_PORT/data
+        rsync -rx "${RSYNC_EXCLUDE_OPTS[@]}" --exclude=lost+found --ignore-existing --ignore-non-existing --delete --itemize-changes --info=stats2,misc2 ${SOURCE}/ rsync://127.0.0.1:$STUNNEL_LISTEN_PORT/data
         rc_b=$?
         rc=$(( rc_a * 100 + rc_b ))
     fi
@@ -186,12 +257,21 @@ while [[ $rc -ne 0 && $RETRY -lt $MAX_RETRIES ]]; do
 done
 set -e  # Exit on command failure
 
+if [[ $rc -eq 0 && -n ${PSK_IDENTITY} ]]; then
+    echo "PSK identity used: ${PSK_IDENTITY}"
+fi
+
 if test -b $BLOCK_SOURCE; then
     echo "diskrsync completed in $(( SECONDS - START_TIME ))s"
 else
     echo "rsync completed in $(( SECONDS - START_TIME ))s"
 
     if [[ $rc -eq 0 ]]; then
+        if [[ -n ${PSK_IDENTITY} ]]; then
+            # Let the destination know which key was used
+            echo "${PSK_IDENTITY}" > /tmp/psk-identity
+            rsync /tmp/psk-identity rsync://127.0.0.1:$STUNNEL_LISTEN_PORT/control/psk-identity
+        fi
         # Tell server to shutdown. Actual file contents don't matter
         echo "Sending shutdown to remote..."
         rsync "$SCRIPT_FULLPATH" rsync://127.0.0.1:$STUNNEL_LISTEN_PORT/control/complete
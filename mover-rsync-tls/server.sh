@@ -5,6 +5,9 @@ set -e -o pipefail
 RSYNC_PID_FILE=/tmp/rsyncd.pid
 CONTROL_FILE=/tmp/control/complete
 CONTROL_FILE_SYMLINK_MUNGING_FILE=/tmp/control/symlink-munging-file
+CONTROL_FILE_PSK_IDENTITY=/tmp/control/psk-identity
+TRANSFER_COUNT_FILE=/tmp/transfer-count
+DISKRSYNC_STATE_FILE=/state/diskrsync-state.json
 RSYNCD_CONF=/tmp/rsyncd.conf
 STUNNEL_CONF=/tmp/stunnel.conf
 STUNNEL_PID_FILE=/tmp/stunnel.pid
@@ -54,15 +57,58 @@ fi
 
 cd "$SCRIPT_DIR"
 
+# Waits for the rsync servers that stunnel spawns for each connection to exit
+# (an exited server that hasn't been reaped has no command line)
+function wait_for_rsync_servers() {
+    local proc
+    for proc in /proc/[0-9]*; do
+        while [[ $(tr '\0' ' ' 2>/dev/null < "$proc/cmdline") == "rsync --server --daemon"* ]]; do
+            echo "Waiting for the rsync server to exit (pid ${proc#/proc/})..."
+            sleep 1
+        done
+    done
+}
+
+if [[ $PERSISTENT -eq 1 ]]; then
+    # A restarted mover has no transfer waiting to be acknowledged
+    rm -f "$TRANSFER_PENDING_FILE"
+fi
+
 STUNNEL_LISTEN_PORT=:::8000
 # If IPv6 is in disable state, the output would be "1"
 if [[ $IPV6_DISABLED -eq 1 ]]; then
     STUNNEL_LISTEN_PORT=8000
 fi
 
-if [[ ! -r $PSK_FILE ]]; then
-    echo "ERROR: Pre-shared key not found - $PSK_FILE"
-    exit 1
+if [[ -n "${TLS_CERT_FILE}" ]]; then
+    ## Certificate-based mutual TLS, clients must present a certificate
+    ## signed by the CA
+    for f in "$TLS_CERT_FILE" "$TLS_KEY_FILE" "$TLS_CA_FILE"; do
+        if [[ ! -r $f ]]; then
+            echo "ERROR: TLS certificate file not found - $f"
+            exit 1
+        fi
+    done
+    TLS_AUTH="cert = $TLS_CERT_FILE
+key = $TLS_KEY_FILE
+CAfile = $TLS_CA_FILE
+verifyChain = yes"
+    if [[ -n ${TLS_CHECK_HOST} ]]; then
+        TLS_AUTH+=$'\n'"checkHost = ${TLS_CHECK_HOST}"
+    fi
+    if [[ -n ${TLS_CIPHERS} ]]; then
+        TLS_AUTH+=$'\n'"ciphers = ${TLS_CIPHERS}"
+    fi
+    if [[ -n ${TLS_CIPHERSUITES} ]]; then
+        TLS_AUTH+=$'\n'"ciphersuites = ${TLS_CIPHERSUITES}"
+    fi
+else
+    if [[ ! -r $PSK_FILE ]]; then
+        echo "ERROR: Pre-shared key not found - $PSK_FILE"
+        exit 1
+    fi
+    TLS_AUTH="ciphers = kPSK
+PSKsecrets = $PSK_FILE"
 fi
 
 TARGET="/data"
@@ -80,7 +126,7 @@ if [[ -n ${SSL_VERSION_MIN} ]]; then
 fi
 
 # Notes:
-# Below we are setting ciphers = kPSK in stunnel.conf as we're already going
+# For TLS-PSK, below we are setting ciphers = kPSK in stunnel.conf as we're already going
 # to default to TLSv1.3 as the minimum.  The ciphers setting only applies to
 # TLSv1.2 and below, but with ciphers = PSK startup will still run a "per-day"
 # regeneration job that will regenerate DH parameters - we can bypass this
@@ -143,8 +189,7 @@ socket = r:TCP_KEEPIDLE=180
 syslog = no
 
 [rsync]
-ciphers = kPSK
-PSKsecrets = $PSK_FILE
+$TLS_AUTH
 $SSLVERSIONMIN
 ; Port to listen for incoming connections from remote
 accept = $STUNNEL_LISTEN_PORT
@@ -162,12 +207,14 @@ STUNNEL_CONF
 
     ##############################
     ## Tail rsync log to stdout so it shows in pod logs
-    touch "$RSYNC_LOG"
+    # (truncated so a persistent destination only shows the current transfer)
+    : > "$RSYNC_LOG"
     tail -f "$RSYNC_LOG" &
     TAIL_PID="$!"
 
     rm -f "$CONTROL_FILE"
     rm -f "$CONTROL_FILE_SYMLINK_MUNGING_FILE"
+    rm -f "$CONTROL_FILE_PSK_IDENTITY"
 fi
 
 if test -b $BLOCK_TARGET; then
@@ -190,8 +237,7 @@ socket = r:TCP_KEEPIDLE=180
 syslog = no
 
 [diskrsync]
-ciphers = kPSK
-PSKsecrets = $PSK_FILE
+$TLS_AUTH
 $SSLVERSIONMIN
 ; Port to listen for incoming connections from remote
 accept = $STUNNEL_LISTEN_PORT
@@ -202,7 +248,13 @@ connect = 8888
 #execargs = diskrsync-tcp $BLOCK_TARGET --target --port 8888 --control-file $CONTROL_FILE
 STUNNEL_CONF
 
-  /diskrsync-tcp $BLOCK_TARGET --target --port 8888 --control-file $CONTROL_FILE&
+  rm -f "$CONTROL_FILE"
+  rm -f "$CONTROL_FILE_PSK_IDENTITY"
+  # Progress of resumable transfers is kept on the state volume, so a transfer
+  # that is interrupted continues where it left off, also after the destination
+  # mover restarts
+  /diskrsync-tcp $BLOCK_TARGET --target --port 8888 --control-file $CONTROL_FILE --state-file "$DISKRSYNC_STATE_FILE" \
+    --psk-identity-file "$CONTROL_FILE_PSK_IDENTITY" &
 fi
 
 ##############################
@@ -220,6 +272,10 @@ done
 
 sleep 5  # Give time for the rsync connection to finish
 
+if [[ -s $CONTROL_FILE_PSK_IDENTITY ]]; then
+    echo "PSK identity used: $(<"$CONTROL_FILE_PSK_IDENTITY")"
+fi
+
 # Before shutting down, read the symlink munging file if it exists, and process
 if [[ -s $CONTROL_FILE_SYMLINK_MUNGING_FILE ]]; then
     echo "Symlink munging file found, processing..."
@@ -246,16 +302,49 @@ fi
 ##############################
 ## Terminate stunnel
 echo "Shutting down..."
-kill -TERM "$(<"$STUNNEL_PID_FILE")"
+STUNNEL_PID="$(<"$STUNNEL_PID_FILE")"
+kill -TERM "$STUNNEL_PID"
 if [[ -d $TARGET ]]; then
     kill -TERM "$TAIL_PID"
 fi
 wait
 echo "Stunnel completed shut down."
 
+if [[ $PERSISTENT -eq 1 ]]; then
+    ##############################
+    ## Nothing may write to the volume while the controller preserves it,
+    ## wait for stunnel and the rsync servers it spawned to exit
+    while kill -0 "$STUNNEL_PID" 2>/dev/null; do
+        sleep 1
+    done
+    wait_for_rsync_servers
+fi
+
 if test -b $BLOCK_TARGET; then
     sync -f $BLOCK_TARGET
 else
     sync -f $TARGET
 fi
+
+if [[ $PERSISTENT -eq 1 ]]; then
+    ##############################
+    ## Long-running destination, wait for the controller to preserve the
+    ## data, then start over to accept the next transfer
+    TRANSFER=$(( $(cat "$TRANSFER_COUNT_FILE" 2>/dev/null || echo 0) + 1 ))
+    echo "$TRANSFER" > "$TRANSFER_COUNT_FILE"
+    # The random part keeps the ID unique when the count starts over in a new
+    # pod, so that an earlier acknowledgement can't match this transfer
+    TRANSFER_ID="${TRANSFER}-$(od -An -N4 -tx1 /dev/urandom | tr -d ' \n')"
+    echo "Transfer ${TRANSFER_ID} completed"
+    # The mover reports not ready until the transfer is acknowledged, so the
+    # controller is notified through the Deployment status
+    touch "$TRANSFER_PENDING_FILE"
+    echo "Waiting for the transfer to be acknowledged..."
+    while [[ "$(cat "$TRANSFER_ACK_FILE" 2>/dev/null)" != "$TRANSFER_ID" ]]; do
+        sleep 1
+    done
+    rm -f "$TRANSFER_PENDING_FILE"
+    echo "Ready for the next transfer"
+    exec "$SCRIPT_FULLPATH"
+fi
 echo "Sync complete, exiting."
@@ -44,10 +44,27 @@ if [[ $DEBUG_MOVER -eq 1 && "$SCRIPT_DIR" != "/tmp" ]]; then
   exit 0
 fi
 
-# Allow source's key to access, but restrict what it can do.
+# Allow source's key to access, but restrict what it can do. After a key
+# rotation, the previous key is also accepted until its grace period ends.
+function write_authorized_keys {
+    local keys=/tmp/authorized_keys
+    : > "$keys"
+    for pub in /keys/source.pub /keys/source.pub.previous; do
+        if [[ -s "$pub" ]]; then
+            echo "command=\"/mover-rsync/destination-command.sh\",restrict $(<"$pub")" >> "$keys"
+        fi
+    done
+    # Replace atomically so sshd never sees a partial file
+    cp "$keys" ~/.ssh/authorized_keys.new
+    mv ~/.ssh/authorized_keys.new ~/.ssh/authorized_keys
+}
+
 mkdir -p ~/.ssh
 chmod 700 ~/.ssh
-echo "command=\"/mover-rsync/destination-command.sh\",restrict $(</keys/source.pub)" > ~/.ssh/authorized_keys
+write_authorized_keys
+# Rotated keys are propagated into the mounted Secret while waiting for a
+# connection, so keep the authorized keys up to date
+while sleep 30; do write_authorized_keys; done &
 
 MOUNT_PATH="/data"
 VOLUME_MODE="filesystem"
@@ -0,0 +1,44 @@
+#! /bin/bash
+# shellcheck disable=SC2034
+
+# Sets the rsync options of the rsync based movers from the env vars set by the
+# operator. This is sourced by mover-rsync/source.sh and
+# mover-rsync-tls/client.sh, which use:
+#   RSYNC_OPTS          - the options from the tuning settings
+#   RSYNC_EXCLUDE_OPTS  - the options to exclude files from the transfer
+
+# Build the rsync options from the tuning settings
+RSYNC_OPTS=(-aAhHSx)
+if [[ "${RSYNC_COMPRESS:-1}" == "1" ]]; then
+    RSYNC_OPTS+=(-z)
+    if [[ -n "${RSYNC_COMPRESS_LEVEL}" ]]; then
+        RSYNC_OPTS+=("--compress-level=${RSYNC_COMPRESS_LEVEL}")
+    fi
+fi
+if [[ -n "${RSYNC_BWLIMIT}" ]]; then
+    RSYNC_OPTS+=("--bwlimit=${RSYNC_BWLIMIT}")
+fi
+if [[ "${RSYNC_CHECKSUM}" == "1" ]]; then
+    RSYNC_OPTS+=(--checksum)
+fi
+if [[ "${RSYNC_PARTIAL}" == "1" ]]; then
+    RSYNC_OPTS+=(--partial)
+fi
+
+# Build the exclude file from the patterns and the exclude ConfigMap. Excluded
+# files are neither sent nor deleted at the destination.
+EXCLUDE_FILE=/tmp/rsync-excludes.txt
+: > "$EXCLUDE_FILE"
+if [[ -n "${RSYNC_EXCLUDE_PATTERNS}" ]]; then
+    printf '%s\n' "${RSYNC_EXCLUDE_PATTERNS}" >> "$EXCLUDE_FILE"
+fi
+if [[ -n "${RSYNC_EXCLUDE_FROM}" ]]; then
+    cat "${RSYNC_EXCLUDE_FROM}" >> "$EXCLUDE_FILE"
+    echo >> "$EXCLUDE_FILE"
+fi
+RSYNC_EXCLUDE_OPTS=()
+if [[ -s "$EXCLUDE_FILE" ]]; then
+    echo "Excluding:"
+    grep -v -E '^\s*([#;]|$)' "$EXCLUDE_FILE" || true
+    RSYNC_EXCLUDE_OPTS+=("--exclude-from=$EXCLUDE_FILE")
+fi
@@ -110,6 +110,9 @@ if [[ "$DESTINATION_ADDRESS" =~ $IPV6_REGEX ]]; then
   fi
 fi
 
+# shellcheck source=mover-rsync/rsync-opts.sh
+source /mover-rsync/rsync-opts.sh
+
 MAX_RETRIES=5
 RETRY=0
 DELAY=2
@@ -126,7 +129,7 @@ do
       echo "calling diskrsync $BLOCK_SOURCE root@${URL_DESTINATION_ADDRESS}:/dev/block"
       diskrsync $BLOCK_SOURCE "root@${URL_DESTINATION_ADDRESS}":/dev/block
     else
-      rsync -aAhHSxz --delete --itemize-changes --info=stats2,misc2 $SOURCE/ "root@${URL_DESTINATION_ADDRESS}":.
+      rsync "${RSYNC_OPTS[@]}" "${RSYNC_EXCLUDE_OPTS[@]}" --delete --itemize-changes --info=stats2,misc2 $SOURCE/ "root@${URL_DESTINATION_ADDRESS}":.
     fi
     rc=$?
     if [[ ${rc} -ne 0 ]]; then